@@ -0,0 +1,7 @@
+package commands
+
+// Default maximum number of parse errors printed.
+const defaultErrorLimit = 20
+
+// Usage of the error limit flag.
+const errorLimitUsage = "Maximum number of parse errors to print, or 0 to print all of them. Defaults to 20."
@@ -81,8 +81,8 @@ func (c *GenerateCommand) Help() string {
 
 	return fmt.Sprintf(`Usage: entangle generate <language> [options] <definition path> <output path>
 
-  Generate an implementation from an Entangle definition file. Options depend
-  on the target language.
+  Generate an implementation from an Entangle definition file and the files it
+  imports. Options depend on the target language.
 
 Arguments:
 
@@ -91,11 +91,24 @@ Arguments:
   <definition path>     Path of the definition file.
   <output path>         Output path of the generated code.
 
+Options:
+
+%s
+
 Target languages:
 
 %s
 
-%s`, strings.Join(targetLanguages, "\n"), strings.Join(languageOptions, "\n\n"))
+%s`, usageList([]usageListElement {
+		{
+			Name: "-I <path>",
+			Synopsis: importPathsUsage,
+		},
+		{
+			Name: "-error-limit <count>",
+			Synopsis: errorLimitUsage,
+		},
+	}), strings.Join(targetLanguages, "\n"), strings.Join(languageOptions, "\n\n"))
 }
 
 func (c *GenerateCommand) Run(args []string) int {
@@ -118,7 +131,12 @@ func (c *GenerateCommand) Run(args []string) int {
 	}
 
 	// Parse the options for the target language.
+	var importPaths importPathsFlag
+	var errorLimit uint
+
 	flagSet, options := targetLanguage.FlagSet()
+	flagSet.Var(&importPaths, "I", importPathsUsage)
+	flagSet.UintVar(&errorLimit, "error-limit", defaultErrorLimit, errorLimitUsage)
 	flagSet.Usage = func() {
 		c.Ui.Output("")
 		c.Ui.Output(c.Help())
@@ -163,41 +181,47 @@ func (c *GenerateCommand) Run(args []string) int {
 	}
 
 	// Parse the file.
-	interfaceDecl, err := parser.Parse(src)
+	interfaceDecl, err := parser.ParseWithOptions(src, &parser.Options {
+		ImportPaths: importPaths,
+	})
 
 	if parseErr, ok := err.(errors.ParseError); ok {
-		parser.PrintError(parseErr)
+		parser.PrintErrors(parseErr, int(errorLimit))
 		return 1
 	} else if err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to parse %s: %v", path, err))
 		return 1
 	}
 
-	// Make sure the output directory exists.
-	interfaceOutputPath := filepath.Join(outputPath, interfaceDecl.Name)
+	// Generate an implementation for the definition and every imported
+	// definition.
+	for _, decl := range collectInterfaces(interfaceDecl, nil) {
+		// Make sure the output directory exists.
+		interfaceOutputPath := filepath.Join(outputPath, decl.Name)
+
+		var interfaceOutputPathStat os.FileInfo
+		var statErr error
 
-	var interfaceOutputPathStat os.FileInfo
-	var statErr error
+		if interfaceOutputPathStat, statErr = os.Stat(interfaceOutputPath); statErr != nil {
+			if !os.IsNotExist(statErr) {
+				c.Ui.Error(fmt.Sprintf("Failed to determine status of output directory '%s': %v", interfaceOutputPath, statErr))
+				return 1
+			}
 
-	if interfaceOutputPathStat, statErr = os.Stat(interfaceOutputPath); statErr != nil {
-		if !os.IsNotExist(statErr) {
-			c.Ui.Error(fmt.Sprintf("Failed to determine status of output directory '%s': %v", interfaceOutputPath, statErr))
+			if statErr = os.MkdirAll(interfaceOutputPath, 0777); statErr != nil {
+				c.Ui.Error(fmt.Sprintf("Failed to create output directory '%s': %v", interfaceOutputPath, statErr))
+				return 1
+			}
+		} else if !interfaceOutputPathStat.Mode().IsDir() {
+			c.Ui.Error(fmt.Sprintf("Output path is not a directory: %s", interfaceOutputPath))
 			return 1
 		}
 
-		if statErr = os.MkdirAll(interfaceOutputPath, 0777); statErr != nil {
-			c.Ui.Error(fmt.Sprintf("Failed to create output directory '%s': %v", interfaceOutputPath, statErr))
+		// Perform the generation.
+		if err = targetLanguage.Generate(decl, interfaceOutputPath, options); err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to generate implementation of %s: %v", decl.Name, err))
 			return 1
 		}
-	} else if !interfaceOutputPathStat.Mode().IsDir() {
-		c.Ui.Error(fmt.Sprintf("Output path is not a directory: %s", interfaceOutputPath))
-		return 1
-	}
-
-	// Perform the generation.
-	if err = targetLanguage.Generate(interfaceDecl, interfaceOutputPath, options); err != nil {
-		c.Ui.Error(fmt.Sprintf("Failed to generate implementation: %v", err))
-		return 1
 	}
 
 	return 0
@@ -8,21 +8,29 @@ import (
 )
 
 // Go target options.
-type goTargetOptions struct {}
+type goTargetOptions struct {
+	// Import path prefix.
+	ImportPrefix string
+}
 
 // Go target flag set.
 func goTargetFlagSet() (s *flag.FlagSet, options interface {}) {
 	o := &goTargetOptions {}
 	s = flag.NewFlagSet("go", flag.ExitOnError)
+	s.StringVar(&o.ImportPrefix, "import-prefix", "", "Go import path prefix of the packages generated from imported definitions. Packages are imported as <prefix>/<definition name>.")
 	options = o
 	return
 }
 
 // Go target generation.
 func goTargetGenerate(interfaceDecl *declarations.Interface, outputPath string, o interface{}) (err error) {
+	options := o.(*goTargetOptions)
+
 	// Initialize the generator.
 	var generator generators.Generator
-	if generator, err = golang.NewGenerator(&golang.Options{}); err != nil {
+	if generator, err = golang.NewGenerator(&golang.Options{
+		ImportPrefix: options.ImportPrefix,
+	}); err != nil {
 		return
 	}
 
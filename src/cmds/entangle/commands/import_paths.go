@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"entangle/declarations"
+	"strings"
+)
+
+// Import paths flag.
+//
+// Can be supplied multiple times to add multiple import search paths.
+type importPathsFlag []string
+
+func (f *importPathsFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *importPathsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// Usage of the import paths flag.
+const importPathsUsage = "Add a directory to the import search paths. Imports are resolved relative to the importing file first and then against the search paths in the order supplied."
+
+// Collect an interface declaration and the interface declarations it
+// transitively imports.
+//
+// Every interface declaration is only collected once.
+func collectInterfaces(interfaceDecl *declarations.Interface, collected []*declarations.Interface) []*declarations.Interface {
+	for _, c := range collected {
+		if c == interfaceDecl {
+			return collected
+		}
+	}
+
+	collected = append(collected, interfaceDecl)
+
+	for _, importDecl := range interfaceDecl.ImportsSortedByName() {
+		collected = collectInterfaces(importDecl.Interface, collected)
+	}
+
+	return collected
+}
@@ -4,6 +4,7 @@ import (
 	"entangle/errors"
 	"entangle/parser"
 	"entangle/source"
+	"flag"
 	"fmt"
 	"github.com/mitchellh/cli"
 	"os"
@@ -11,18 +12,49 @@ import (
 
 // Validate command.
 //
-// Validates a definition file by parsing it and outputting any errors.
+// Validates a definition file by parsing it and outputting any errors, as well
+// as warnings about references to deprecated declarations.
 type ValidateCommand struct {
 	Ui cli.Ui
 }
 
 func (c *ValidateCommand) Help() string {
-	return `Usage: entangle validate <path>
+	return fmt.Sprintf(`Usage: entangle validate [options] <path>
 
-  Validate an Entangle definition file.`
+  Validate an Entangle definition file and the files it imports.
+
+Options:
+
+%s`, usageList([]usageListElement{
+		{
+			Name:     "-I <path>",
+			Synopsis: importPathsUsage,
+		},
+		{
+			Name:     "-error-limit <count>",
+			Synopsis: errorLimitUsage,
+		},
+	}))
 }
 
 func (c *ValidateCommand) Run(args []string) int {
+	// Parse the options.
+	var importPaths importPathsFlag
+	var errorLimit uint
+
+	flagSet := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flagSet.Var(&importPaths, "I", importPathsUsage)
+	flagSet.UintVar(&errorLimit, "error-limit", defaultErrorLimit, errorLimitUsage)
+	flagSet.Usage = func() {
+		c.Ui.Output("")
+		c.Ui.Output(c.Help())
+	}
+	if err := flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flagSet.Args()
+
 	// Parse the path from the arguments.
 	if len(args) != 1 {
 		if len(args) == 0 {
@@ -54,16 +86,23 @@ func (c *ValidateCommand) Run(args []string) int {
 	}
 
 	// Parse the file.
-	_, err = parser.Parse(src)
+	decl, err := parser.ParseWithOptions(src, &parser.Options{
+		ImportPaths: importPaths,
+	})
 
 	if parseErr, ok := err.(errors.ParseError); ok {
-		parser.PrintError(parseErr)
+		parser.PrintErrors(parseErr, int(errorLimit))
 		return 1
 	} else if err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to parse %s: %v", path, err))
 		return 1
 	}
 
+	// Warn about references to deprecated declarations.
+	for _, ref := range decl.DeprecatedReferences() {
+		c.Ui.Warn(fmt.Sprintf("Warning: %s references deprecated %s", ref.Referrer, ref.Deprecated))
+	}
+
 	return 0
 }
 
@@ -28,7 +28,7 @@ func main() {
 	interfaceDecl, err := parser.Parse(src)
 
 	if parseErr, ok := err.(errors.ParseError); ok {
-		parser.PrintError(parseErr)
+		parser.PrintErrors(parseErr, 0)
 	} else {
 		fmt.Println(err)
 	}
@@ -0,0 +1,126 @@
+package declarations
+
+import (
+	"reflect"
+)
+
+// Annotation argument.
+type AnnotationArgument struct {
+	// Key.
+	//
+	// Empty for positional arguments.
+	Key string
+
+	// Value.
+	//
+	// The dynamic type of the value is bool, string, time.Duration for
+	// durations, float64 for floating point numbers, int64 for negative
+	// integers or uint64 for non-negative integers.
+	Value interface{}
+}
+
+// Annotation.
+//
+// Annotations attach metadata to declarations. The meaning of an annotation
+// is left to the consumers of the declarations, and annotations unknown to a
+// consumer are expected to be ignored.
+type Annotation struct {
+	// Name.
+	//
+	// Names can be qualified by dot separated prefixes, e.g. "go.name".
+	Name string
+
+	// Arguments in the order of declaration.
+	Arguments []*AnnotationArgument
+}
+
+// Get an argument by key.
+//
+// Returns nil if the annotation has no argument with the key.
+func (a *Annotation) Argument(key string) *AnnotationArgument {
+	for _, arg := range a.Arguments {
+		if arg.Key == key {
+			return arg
+		}
+	}
+
+	return nil
+}
+
+// Get the positional arguments.
+func (a *Annotation) PositionalArguments() []*AnnotationArgument {
+	positional := make([]*AnnotationArgument, 0, len(a.Arguments))
+
+	for _, arg := range a.Arguments {
+		if arg.Key == "" {
+			positional = append(positional, arg)
+		}
+	}
+
+	return positional
+}
+
+// Annotation list.
+//
+// Annotations in the order of declaration.
+type Annotations []*Annotation
+
+// Get an annotation by name.
+//
+// Returns nil if no annotation has the name.
+func (l Annotations) Annotation(name string) *Annotation {
+	for _, a := range l {
+		if a.Name == name {
+			return a
+		}
+	}
+
+	return nil
+}
+
+// Determine if an annotation is present.
+func (l Annotations) Has(name string) bool {
+	return l.Annotation(name) != nil
+}
+
+// Determine if two annotation lists are the same.
+//
+// Lists are the same if they contain the same annotations with the same
+// arguments in the same order.
+func (l Annotations) Same(o Annotations) bool {
+	if len(l) != len(o) {
+		return false
+	}
+
+	for i, a := range l {
+		if !reflect.DeepEqual(a, o[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Name of the annotation marking declarations as deprecated.
+//
+// The annotation takes an optional reason as its only argument, either
+// positional or by the key "reason".
+const DeprecatedAnnotation = "deprecated"
+
+// Determine if the annotated declaration is deprecated.
+func (l Annotations) Deprecated() bool {
+	return l.Has(DeprecatedAnnotation)
+}
+
+// Reason for the deprecation of the annotated declaration.
+//
+// Empty if the declaration is not deprecated or no reason is given.
+func (l Annotations) DeprecationReason() string {
+	a := l.Annotation(DeprecatedAnnotation)
+	if a == nil || len(a.Arguments) == 0 {
+		return ""
+	}
+
+	reason, _ := a.Arguments[0].Value.(string)
+	return reason
+}
@@ -0,0 +1,49 @@
+package declarations
+
+// Constant declaration.
+type Constant struct {
+	// Constant name.
+	Name string
+
+	// Documentation paragraphs.
+	Documentation []string
+
+	// Annotations.
+	Annotations Annotations
+
+	// Type.
+	Type Type
+
+	// Value.
+	//
+	// The dynamic type of the value depends on the class of the type: bool
+	// for bools, string for strings, float64 for floating point numbers,
+	// int64 for signed integers, uint64 for unsigned integers and EnumValue
+	// for enumerations.
+	Value interface{}
+}
+
+// New constant declaration.
+func NewConstant(name string, documentation []string, constantType Type, value interface{}) *Constant {
+	return &Constant{
+		Name:          name,
+		Documentation: documentation,
+		Type:          constantType,
+		Value:         value,
+	}
+}
+
+// Constants by name.
+type constantsByName []*Constant
+
+func (l constantsByName) Len() int {
+	return len(l)
+}
+
+func (l constantsByName) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+func (l constantsByName) Less(i, j int) bool {
+	return l[i].Name < l[j].Name
+}
@@ -0,0 +1,95 @@
+package declarations
+
+// Names of the annotations declaring value constraints on fields and
+// arguments.
+const (
+	// Range of numeric values.
+	//
+	// Takes an inclusive minimum and maximum by the keys "min" and "max", of
+	// which at least one must be given.
+	RangeAnnotation = "range"
+
+	// Length of strings, binary values and lists.
+	//
+	// Takes an inclusive minimum and maximum by the keys "min" and "max", of
+	// which at least one must be given. The length of a string is the number
+	// of Unicode code points in the string.
+	LengthAnnotation = "length"
+
+	// Regular expression which strings must match.
+	//
+	// Takes the regular expression as its only argument. The expression is
+	// matched anywhere in the string unless anchored.
+	PatternAnnotation = "pattern"
+
+	// Maps must not be empty.
+	NonEmptyAnnotation = "nonempty"
+)
+
+// Value constraints.
+type Constraints struct {
+	// Minimum value.
+	//
+	// Nil if the value is not bounded from below. Otherwise, the dynamic type
+	// of the bound is float64, int64 or uint64 as described by
+	// AnnotationArgument.
+	Min interface{}
+
+	// Maximum value.
+	//
+	// Nil if the value is not bounded from above.
+	Max interface{}
+
+	// Minimum length.
+	//
+	// Nil if the length is not bounded from below. Otherwise, the dynamic
+	// type of the bound is uint64.
+	MinLength interface{}
+
+	// Maximum length.
+	//
+	// Nil if the length is not bounded from above.
+	MaxLength interface{}
+
+	// Regular expression.
+	//
+	// Empty if values are not required to match a pattern.
+	Pattern string
+
+	// Values must not be empty.
+	NonEmpty bool
+}
+
+// Determine if any constraints are declared.
+func (c Constraints) Any() bool {
+	return c.Min != nil || c.Max != nil || c.MinLength != nil || c.MaxLength != nil || c.Pattern != "" || c.NonEmpty
+}
+
+// Value constraints declared by the annotations.
+func (l Annotations) Constraints() (c Constraints) {
+	bound := func(a *Annotation, key string) interface{} {
+		if arg := a.Argument(key); arg != nil {
+			return arg.Value
+		}
+
+		return nil
+	}
+
+	if a := l.Annotation(RangeAnnotation); a != nil {
+		c.Min = bound(a, "min")
+		c.Max = bound(a, "max")
+	}
+
+	if a := l.Annotation(LengthAnnotation); a != nil {
+		c.MinLength = bound(a, "min")
+		c.MaxLength = bound(a, "max")
+	}
+
+	if a := l.Annotation(PatternAnnotation); a != nil && len(a.Arguments) > 0 {
+		c.Pattern, _ = a.Arguments[0].Value.(string)
+	}
+
+	c.NonEmpty = l.Has(NonEmptyAnnotation)
+
+	return
+}
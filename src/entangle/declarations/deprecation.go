@@ -0,0 +1,186 @@
+package declarations
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Reference to a deprecated declaration.
+type DeprecatedReference struct {
+	// Description of the referencing declaration, e.g. "field User.Role".
+	Referrer string
+
+	// Qualified name of the referenced deprecated declaration.
+	Deprecated string
+}
+
+// Qualified names of the deprecated declarations referenced by a type.
+//
+// Type aliases are not followed, as a reference to a deprecated type through
+// a type alias is a reference by the type alias.
+func deprecatedTypeNames(typeDecl Type) []string {
+	qualified := func(imp *Import, name string) string {
+		if imp == nil {
+			return name
+		}
+
+		return fmt.Sprintf("%s.%s", imp.Name, name)
+	}
+
+	switch t := typeDecl.(type) {
+	case *StructType:
+		if t.Struct().Annotations.Deprecated() {
+			return []string{qualified(t.Import(), t.Struct().Name)}
+		}
+
+	case *EnumType:
+		if t.Enum().Annotations.Deprecated() {
+			return []string{qualified(t.Import(), t.Enum().Name)}
+		}
+
+	case *UnionType:
+		if t.Union().Annotations.Deprecated() {
+			return []string{qualified(t.Import(), t.Union().Name)}
+		}
+
+	case *TypedefType:
+		if t.Typedef().Annotations.Deprecated() {
+			return []string{qualified(t.Import(), t.Typedef().Name)}
+		}
+
+	case *ListType:
+		return deprecatedTypeNames(t.ElementType())
+
+	case *SetType:
+		return deprecatedTypeNames(t.ElementType())
+
+	case *MapType:
+		return append(deprecatedTypeNames(t.KeyType()), deprecatedTypeNames(t.ValueType())...)
+	}
+
+	return nil
+}
+
+// References to deprecated declarations by declarations which are not
+// deprecated themselves.
+//
+// A declaration is considered deprecated if it, or the struct, exception,
+// union or service it is part of, is deprecated. References are ordered by
+// referrer.
+func (i *Interface) DeprecatedReferences() []DeprecatedReference {
+	references := []DeprecatedReference{}
+
+	add := func(referrer string, typeDecl Type) {
+		for _, name := range deprecatedTypeNames(typeDecl) {
+			references = append(references, DeprecatedReference{
+				Referrer:   referrer,
+				Deprecated: name,
+			})
+		}
+	}
+
+	for _, constDecl := range i.Constants {
+		if !constDecl.Annotations.Deprecated() {
+			add(fmt.Sprintf("constant %s", constDecl.Name), constDecl.Type)
+		}
+	}
+
+	for _, typedefDecl := range i.Typedefs {
+		if !typedefDecl.Annotations.Deprecated() {
+			add(fmt.Sprintf("type alias %s", typedefDecl.Name), typedefDecl.Type)
+		}
+	}
+
+	addFields := func(desc, name string, annotations Annotations, fields []*Field, inherited func(*Field) bool) {
+		if annotations.Deprecated() {
+			return
+		}
+
+		for _, field := range fields {
+			if !field.Annotations.Deprecated() && !inherited(field) {
+				add(fmt.Sprintf("%s %s.%s", desc, name, field.Name), field.Type)
+			}
+		}
+	}
+
+	notInherited := func(*Field) bool {
+		return false
+	}
+
+	for _, structDecl := range i.Structs {
+		inherited := notInherited
+
+		if parentDecl, ok := i.Structs[structDecl.ParentName]; ok {
+			inherited = func(field *Field) bool {
+				return parentDecl.FieldIndexInUse(field.Index)
+			}
+		}
+
+		addFields("field", structDecl.Name, structDecl.Annotations, structDecl.Fields, inherited)
+	}
+
+	for _, excDecl := range i.Exceptions {
+		addFields("field", excDecl.Name, excDecl.Annotations, excDecl.Fields, notInherited)
+	}
+
+	for _, unionDecl := range i.Unions {
+		addFields("member", unionDecl.Name, unionDecl.Annotations, unionDecl.Fields, notInherited)
+	}
+
+	for _, serviceDecl := range i.Services {
+		if serviceDecl.Annotations.Deprecated() {
+			continue
+		}
+
+		for _, functionDecl := range serviceDecl.DeclaredFunctionsSortedByName() {
+			if functionDecl.Annotations.Deprecated() {
+				continue
+			}
+
+			name := fmt.Sprintf("%s.%s", serviceDecl.Name, functionDecl.Name)
+
+			for _, arg := range functionDecl.Arguments {
+				if !arg.Annotations.Deprecated() {
+					add(fmt.Sprintf("argument %s of function %s", arg.Name, name), arg.Type)
+				}
+			}
+
+			if functionDecl.ReturnType != nil {
+				add(fmt.Sprintf("return type of function %s", name), functionDecl.ReturnType)
+			}
+
+			for _, thrown := range functionDecl.Throws {
+				if thrown.Exception.Annotations.Deprecated() {
+					deprecated := thrown.Exception.Name
+					if thrown.Import != nil {
+						deprecated = fmt.Sprintf("%s.%s", thrown.Import.Name, deprecated)
+					}
+
+					references = append(references, DeprecatedReference{
+						Referrer:   fmt.Sprintf("exceptions of function %s", name),
+						Deprecated: deprecated,
+					})
+				}
+			}
+		}
+	}
+
+	sort.Stable(deprecatedReferencesByReferrer(references))
+
+	return references
+}
+
+// Deprecated references by referrer.
+type deprecatedReferencesByReferrer []DeprecatedReference
+
+func (l deprecatedReferencesByReferrer) Len() int {
+	return len(l)
+}
+
+func (l deprecatedReferencesByReferrer) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+func (l deprecatedReferencesByReferrer) Less(i, j int) bool {
+	return l[i].Referrer < l[j].Referrer
+}
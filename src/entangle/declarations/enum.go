@@ -4,6 +4,17 @@ import (
 	"sort"
 )
 
+// Name of the annotation marking enumerations as open.
+//
+// Unknown values of open enumerations are preserved rather than rejected.
+const OpenAnnotation = "open"
+
+// Name of the annotation marking an enumeration value as the fallback value.
+//
+// Unknown values of an enumeration with a fallback value are replaced by the
+// fallback value rather than rejected.
+const FallbackAnnotation = "fallback"
+
 // Enumeration value declaration.
 type EnumValue struct {
 	// Value.
@@ -14,6 +25,9 @@ type EnumValue struct {
 
 	// Documentation paragraphs.
 	Documentation []string
+
+	// Annotations.
+	Annotations Annotations
 }
 
 // Enumeration declaration.
@@ -24,6 +38,14 @@ type Enum struct {
 	// Documentation paragraphs.
 	Documentation []string
 
+	// Annotations.
+	Annotations Annotations
+
+	// Flags.
+	//
+	// Values of flags are powers of two, which may be combined.
+	Flags bool
+
 	// Values.
 	//
 	// Mapping of values to representation.
@@ -40,12 +62,31 @@ func NewEnum(name string, documentation []string) *Enum {
 }
 
 // Add a value.
-func (e *Enum) AddValue(value int64, name string, documentation []string) {
+func (e *Enum) AddValue(value int64, name string, documentation []string, annotations Annotations) {
 	e.Values[value] = EnumValue{
 		Value:         value,
 		Name:          name,
 		Documentation: documentation,
+		Annotations:   annotations,
+	}
+}
+
+// Determine if the enumeration is open.
+func (e *Enum) Open() bool {
+	return e.Annotations.Has(OpenAnnotation)
+}
+
+// Fallback value.
+//
+// Nil if no value is marked as the fallback value.
+func (e *Enum) Fallback() *EnumValue {
+	for _, value := range e.ValuesSortedByValue() {
+		if value.Annotations.Has(FallbackAnnotation) {
+			return &value
+		}
 	}
+
+	return nil
 }
 
 // Determine if a value is in use.
@@ -54,6 +95,17 @@ func (e *Enum) ValueInUse(value int64) bool {
 	return inUse
 }
 
+// Get a value by name.
+func (e *Enum) ValueByName(name string) (value EnumValue, found bool) {
+	for _, value = range e.Values {
+		if value.Name == name {
+			return value, true
+		}
+	}
+
+	return EnumValue{}, false
+}
+
 // Enums by name.
 type enumsByName []*Enum
 
@@ -7,6 +7,12 @@ type Exception struct {
 
 	// Documentation paragraphs.
 	Documentation []string
+
+	// Annotations.
+	Annotations Annotations
+
+	// Fields.
+	FieldList
 }
 
 // New exception declaration.
@@ -14,6 +20,7 @@ func NewException(name string, documentation []string) *Exception {
 	return &Exception{
 		Name:          name,
 		Documentation: documentation,
+		FieldList:     newFieldList(),
 	}
 }
 
@@ -11,8 +11,22 @@ type Field struct {
 	// Documentation paragraphs.
 	Documentation []string
 
+	// Annotations.
+	Annotations Annotations
+
 	// Type.
 	Type Type
+
+	// Default value.
+	//
+	// Nil if the field has no default value. Otherwise, the dynamic type of
+	// the value is as described by Constant.
+	Default interface{}
+}
+
+// Determine if the field has a default value.
+func (f *Field) HasDefault() bool {
+	return f.Default != nil
 }
 
 // Fields by index.
@@ -0,0 +1,122 @@
+package declarations
+
+import (
+	"sort"
+)
+
+// Indexed field list.
+//
+// Shared by declarations carrying indexed fields, i.e. structs and exceptions.
+type FieldList struct {
+	ReservationList
+
+	// Fields.
+	//
+	// Do not modify this slice directly. Always use AddField.
+	Fields []*Field
+
+	// Field name mapping.
+	fieldNameMapping map[string]*Field
+
+	// Field index mapping.
+	fieldIndexMapping map[uint]*Field
+}
+
+// New field list.
+func newFieldList() FieldList {
+	return FieldList{
+		Fields:            []*Field{},
+		fieldNameMapping:  map[string]*Field{},
+		fieldIndexMapping: map[uint]*Field{},
+	}
+}
+
+// Add a field.
+//
+// The caller is expected to have validated that neither the name nor index are
+// in use before calling AddField.
+func (l *FieldList) AddField(index uint, name string, documentation []string, fieldType Type) *Field {
+	field := &Field{
+		Index:         index,
+		Name:          name,
+		Documentation: documentation,
+		Type:          fieldType,
+	}
+
+	l.addField(field)
+
+	return field
+}
+
+// Add an existing field declaration.
+func (l *FieldList) addField(field *Field) {
+	l.Fields = append(l.Fields, field)
+	l.fieldNameMapping[field.Name] = field
+	l.fieldIndexMapping[field.Index] = field
+}
+
+// Determine if a field index is in use.
+func (l *FieldList) FieldIndexInUse(index uint) bool {
+	_, inUse := l.fieldIndexMapping[index]
+	return inUse
+}
+
+// Determine if a field name is in use.
+func (l *FieldList) FieldNameInUse(name string) bool {
+	_, inUse := l.fieldNameMapping[name]
+	return inUse
+}
+
+// Field using an index or name reserved by a reservation.
+//
+// Returns nil if no field uses the reservation.
+func (l *FieldList) FieldUsingReservation(r *Reservation) *Field {
+	for _, field := range l.Fields {
+		if r.ReservesIndexes(field.Index, field.Index) || r.ReservesName(field.Name) {
+			return field
+		}
+	}
+
+	return nil
+}
+
+// Sorted list of fields by index.
+func (l *FieldList) FieldsSortedByIndex() []*Field {
+	unsorted := make([]*Field, len(l.Fields))
+
+	idx := 0
+	for _, field := range l.Fields {
+		unsorted[idx] = field
+		idx++
+	}
+
+	sort.Sort(fieldsByIndex(unsorted))
+
+	return unsorted
+}
+
+// Minimum length of deserialized array.
+func (l *FieldList) MinimumDeserializedLength() (minimum int) {
+	minIndex := uint(0)
+
+	for _, field := range l.Fields {
+		if !field.Type.Nilable() && !field.HasDefault() && minIndex < field.Index {
+			minIndex = field.Index
+		}
+	}
+
+	return int(minIndex)
+}
+
+// Length of serialized array.
+func (l *FieldList) SerializedLength() (length int) {
+	maxIndex := uint(0)
+
+	for _, field := range l.Fields {
+		if field.Index > maxIndex {
+			maxIndex = field.Index
+		}
+	}
+
+	return int(maxIndex)
+}
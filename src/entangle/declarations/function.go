@@ -1,9 +1,25 @@
 package declarations
 
 import (
+	"reflect"
 	"sort"
+	"time"
 )
 
+// Name of the annotation declaring the timeout of a function.
+//
+// The annotation takes a positive duration as its only argument. Servers
+// report calls running longer than the timeout, and clients use the timeout
+// as the default deadline of calls.
+const TimeoutAnnotation = "timeout"
+
+// Name of the annotation marking functions as idempotent.
+//
+// Calling an idempotent function more than once has the same effect as
+// calling it once, so clients may retry calls failing due to connection
+// failures.
+const IdempotentAnnotation = "idempotent"
+
 // Function argument declaration.
 type FunctionArgument struct {
 	// Index.
@@ -12,18 +28,57 @@ type FunctionArgument struct {
 	// Name.
 	Name string
 
+	// Documentation paragraphs.
+	Documentation []string
+
 	// Type.
 	Type Type
+
+	// Annotations.
+	Annotations Annotations
+
+	// Default value.
+	//
+	// Nil if the argument has no default value. Otherwise, the dynamic type
+	// of the value is as described by Constant.
+	Default interface{}
+}
+
+// Determine if the argument has a default value.
+func (a *FunctionArgument) HasDefault() bool {
+	return a.Default != nil
+}
+
+// Exception declared to be raised by a function.
+type ThrownException struct {
+	// Exception declaration.
+	Exception *Exception
+
+	// Import through which the exception is declared.
+	//
+	// Nil if the exception is declared in the same interface.
+	Import *Import
 }
 
 // Function declaration.
 type Function struct {
+	ReservationList
+
 	// Service name.
 	Name string
 
 	// Documentation paragraphs.
 	Documentation []string
 
+	// Annotations.
+	Annotations Annotations
+
+	// One-way.
+	//
+	// If true, the function can only be called by notification and returns
+	// no value.
+	Oneway bool
+
 	// FunctionArguments.
 	//
 	// Do not modify this slice directly. Always use AddFunctionArgument.
@@ -34,6 +89,9 @@ type Function struct {
 	// If no return type is defined, this is considered a void function.
 	ReturnType Type
 
+	// Exceptions declared to be raised by the function.
+	Throws []*ThrownException
+
 	// FunctionArgument name mapping.
 	argumentNameMapping map[string]*FunctionArgument
 
@@ -56,16 +114,19 @@ func NewFunction(name string, documentation []string) *Function {
 //
 // The caller is expected to have validated that neither the name nor index are
 // in use before calling AddFunctionArgument.
-func (s *Function) AddArgument(index uint, name string, argumentType Type) {
+func (s *Function) AddArgument(index uint, name string, documentation []string, argumentType Type) *FunctionArgument {
 	argument := &FunctionArgument{
-		Index: index,
-		Name:  name,
-		Type:  argumentType,
+		Index:         index,
+		Name:          name,
+		Documentation: documentation,
+		Type:          argumentType,
 	}
 
 	s.Arguments = append(s.Arguments, argument)
 	s.argumentNameMapping[name] = argument
 	s.argumentIndexMapping[index] = argument
+
+	return argument
 }
 
 // Determine if a argument index is in use.
@@ -80,6 +141,82 @@ func (s *Function) ArgumentNameInUse(name string) bool {
 	return inUse
 }
 
+// Argument using an index or name reserved by a reservation.
+//
+// Returns nil if no argument uses the reservation.
+func (f *Function) ArgumentUsingReservation(r *Reservation) *FunctionArgument {
+	for _, arg := range f.Arguments {
+		if r.ReservesIndexes(arg.Index, arg.Index) || r.ReservesName(arg.Name) {
+			return arg
+		}
+	}
+
+	return nil
+}
+
+// Timeout of the function.
+//
+// Zero if no timeout is declared.
+func (f *Function) Timeout() time.Duration {
+	a := f.Annotations.Annotation(TimeoutAnnotation)
+	if a == nil || len(a.Arguments) == 0 {
+		return 0
+	}
+
+	timeout, _ := a.Arguments[0].Value.(time.Duration)
+	return timeout
+}
+
+// Determine if the function is idempotent.
+func (f *Function) Idempotent() bool {
+	return f.Annotations.Has(IdempotentAnnotation)
+}
+
+// Determine if an exception is declared to be raised by the function.
+func (f *Function) ThrowsException(exc *Exception) bool {
+	for _, thrown := range f.Throws {
+		if thrown.Exception == exc {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Determine if two functions have the same signature.
+//
+// Functions have the same signature if they have the same name, annotations,
+// arguments, return type and declared exceptions. Arguments must also agree
+// on their annotations and default values.
+func (f *Function) SameSignature(o *Function) bool {
+	if f.Name != o.Name || f.Oneway != o.Oneway || len(f.Arguments) != len(o.Arguments) || !f.Annotations.Same(o.Annotations) {
+		return false
+	}
+
+	for _, arg := range f.Arguments {
+		otherArg, found := o.argumentIndexMapping[arg.Index]
+		if !found || otherArg.Name != arg.Name || !SameType(arg.Type, otherArg.Type) || !arg.Annotations.Same(otherArg.Annotations) || !reflect.DeepEqual(arg.Default, otherArg.Default) {
+			return false
+		}
+	}
+
+	if len(f.Throws) != len(o.Throws) {
+		return false
+	}
+
+	for _, thrown := range f.Throws {
+		if !o.ThrowsException(thrown.Exception) {
+			return false
+		}
+	}
+
+	if f.ReturnType == nil || o.ReturnType == nil {
+		return f.ReturnType == nil && o.ReturnType == nil
+	}
+
+	return SameType(f.ReturnType, o.ReturnType)
+}
+
 // Functions by name.
 type functionsByName []*Function
 
@@ -130,7 +267,7 @@ func (f *Function) MinimumDeserializedLength() (minimum int) {
 	minIndex := uint(0)
 
 	for _, arg := range f.Arguments {
-		if !arg.Type.Nilable() && minIndex < arg.Index {
+		if !arg.Type.Nilable() && !arg.HasDefault() && minIndex < arg.Index {
 			minIndex = arg.Index
 		}
 	}
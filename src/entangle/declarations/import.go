@@ -0,0 +1,39 @@
+package declarations
+
+// Import declaration.
+type Import struct {
+	// Import name.
+	//
+	// Types from the imported interface are referenced as <name>.<type>.
+	Name string
+
+	// Import path as declared.
+	Path string
+
+	// Imported interface declaration.
+	Interface *Interface
+}
+
+// New import declaration.
+func NewImport(name, path string, interfaceDecl *Interface) *Import {
+	return &Import{
+		Name:      name,
+		Path:      path,
+		Interface: interfaceDecl,
+	}
+}
+
+// Imports by name.
+type importsByName []*Import
+
+func (l importsByName) Len() int {
+	return len(l)
+}
+
+func (l importsByName) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+func (l importsByName) Less(i, j int) bool {
+	return l[i].Name < l[j].Name
+}
@@ -10,12 +10,24 @@ type Interface struct {
 	// Interface name.
 	Name string
 
+	// Import declarations.
+	Imports map[string]*Import
+
+	// Constant declarations.
+	Constants map[string]*Constant
+
+	// Type alias declarations.
+	Typedefs map[string]*Typedef
+
 	// Struct declarations.
 	Structs map[string]*Struct
 
 	// Exception declarations.
 	Exceptions map[string]*Exception
 
+	// Union declarations.
+	Unions map[string]*Union
+
 	// Enumeration declarations.
 	Enums map[string]*Enum
 
@@ -29,14 +41,36 @@ type Interface struct {
 // New interface declaration.
 func NewInterface() *Interface {
 	return &Interface{
+		Imports:    map[string]*Import{},
+		Constants:  map[string]*Constant{},
+		Typedefs:   map[string]*Typedef{},
 		Structs:    map[string]*Struct{},
 		Exceptions: map[string]*Exception{},
+		Unions:     map[string]*Union{},
 		Enums:      map[string]*Enum{},
 		Services:   map[string]*Service{},
 		usedNames:  make(utils.StringSet),
 	}
 }
 
+// Add an import and mark its name as used.
+func (i *Interface) AddImport(decl *Import) {
+	i.Imports[decl.Name] = decl
+	i.MarkNameAsUsed(decl.Name)
+}
+
+// Add a constant and mark its name as used.
+func (i *Interface) AddConstant(decl *Constant) {
+	i.Constants[decl.Name] = decl
+	i.MarkNameAsUsed(decl.Name)
+}
+
+// Add a type alias and mark its name as used.
+func (i *Interface) AddTypedef(decl *Typedef) {
+	i.Typedefs[decl.Name] = decl
+	i.MarkNameAsUsed(decl.Name)
+}
+
 // Add a struct and mark its name as used.
 func (i *Interface) AddStruct(decl *Struct) {
 	i.Structs[decl.Name] = decl
@@ -49,6 +83,12 @@ func (i *Interface) AddException(decl *Exception) {
 	i.MarkNameAsUsed(decl.Name)
 }
 
+// Add a union and mark its name as used.
+func (i *Interface) AddUnion(decl *Union) {
+	i.Unions[decl.Name] = decl
+	i.MarkNameAsUsed(decl.Name)
+}
+
 // Add an enumeration and mark its name as used.
 func (i *Interface) AddEnum(decl *Enum) {
 	i.Enums[decl.Name] = decl
@@ -71,6 +111,92 @@ func (i *Interface) MarkNameAsUsed(name string) {
 	i.usedNames.Add(name)
 }
 
+// Sorted list of imports by name.
+func (i *Interface) ImportsSortedByName() []*Import {
+	unsorted := make([]*Import, len(i.Imports))
+
+	idx := 0
+	for _, imp := range i.Imports {
+		unsorted[idx] = imp
+		idx++
+	}
+
+	sort.Sort(importsByName(unsorted))
+
+	return unsorted
+}
+
+// Sorted list of constants by name.
+func (i *Interface) ConstantsSortedByName() []*Constant {
+	unsorted := make([]*Constant, len(i.Constants))
+
+	idx := 0
+	for _, constant := range i.Constants {
+		unsorted[idx] = constant
+		idx++
+	}
+
+	sort.Sort(constantsByName(unsorted))
+
+	return unsorted
+}
+
+// Sorted list of type aliases by name.
+func (i *Interface) TypedefsSortedByName() []*Typedef {
+	unsorted := make([]*Typedef, len(i.Typedefs))
+
+	idx := 0
+	for _, typedef := range i.Typedefs {
+		unsorted[idx] = typedef
+		idx++
+	}
+
+	sort.Sort(typedefsByName(unsorted))
+
+	return unsorted
+}
+
+// Sorted list of structs by name.
+func (i *Interface) StructsSortedByName() []*Struct {
+	unsorted := make([]*Struct, len(i.Structs))
+
+	idx := 0
+	for _, strct := range i.Structs {
+		unsorted[idx] = strct
+		idx++
+	}
+
+	sort.Sort(structsByName(unsorted))
+
+	return unsorted
+}
+
+// Sorted list of structs with parent structs preceding their children.
+func (i *Interface) StructsSortedByInheritance() []*Struct {
+	sorted := make([]*Struct, 0, len(i.Structs))
+	added := make(map[*Struct]bool, len(i.Structs))
+
+	var add func(strct *Struct)
+	add = func(strct *Struct) {
+		if added[strct] {
+			return
+		}
+
+		if strct.Parent != nil {
+			add(strct.Parent)
+		}
+
+		added[strct] = true
+		sorted = append(sorted, strct)
+	}
+
+	for _, strct := range i.StructsSortedByName() {
+		add(strct)
+	}
+
+	return sorted
+}
+
 // Sorted list of exceptions by name.
 func (i *Interface) ExceptionsSortedByName() []*Exception {
 	unsorted := make([]*Exception, len(i.Exceptions))
@@ -86,6 +212,21 @@ func (i *Interface) ExceptionsSortedByName() []*Exception {
 	return unsorted
 }
 
+// Sorted list of unions by name.
+func (i *Interface) UnionsSortedByName() []*Union {
+	unsorted := make([]*Union, len(i.Unions))
+
+	idx := 0
+	for _, union := range i.Unions {
+		unsorted[idx] = union
+		idx++
+	}
+
+	sort.Sort(unionsByName(unsorted))
+
+	return unsorted
+}
+
 // Sorted list of enumerations by name.
 func (i *Interface) EnumsSortedByName() []*Enum {
 	unsorted := make([]*Enum, len(i.Enums))
@@ -100,3 +241,44 @@ func (i *Interface) EnumsSortedByName() []*Enum {
 
 	return unsorted
 }
+
+// Sorted list of services by name.
+func (i *Interface) ServicesSortedByName() []*Service {
+	unsorted := make([]*Service, len(i.Services))
+
+	idx := 0
+	for _, srvc := range i.Services {
+		unsorted[idx] = srvc
+		idx++
+	}
+
+	sort.Sort(servicesByName(unsorted))
+
+	return unsorted
+}
+
+// Sorted list of services with parent services preceding their children.
+func (i *Interface) ServicesSortedByInheritance() []*Service {
+	sorted := make([]*Service, 0, len(i.Services))
+	added := make(map[*Service]bool, len(i.Services))
+
+	var add func(srvc *Service)
+	add = func(srvc *Service) {
+		if added[srvc] {
+			return
+		}
+
+		if srvc.Parent != nil {
+			add(srvc.Parent)
+		}
+
+		added[srvc] = true
+		sorted = append(sorted, srvc)
+	}
+
+	for _, srvc := range i.ServicesSortedByName() {
+		add(srvc)
+	}
+
+	return sorted
+}
@@ -0,0 +1,89 @@
+package declarations
+
+// Reservation of indexes or a name.
+//
+// Reserved indexes and names cannot be used by fields or arguments, which
+// prevents reusing those of removed declarations.
+type Reservation struct {
+	// First reserved index.
+	//
+	// Zero if a name is reserved.
+	FirstIndex uint
+
+	// Last reserved index.
+	//
+	// Zero if a name is reserved.
+	LastIndex uint
+
+	// Reserved name.
+	//
+	// Empty if indexes are reserved.
+	Name string
+}
+
+// Determine if the reservation reserves any index of a range of indexes.
+func (r *Reservation) ReservesIndexes(first, last uint) bool {
+	return r.Name == "" && first <= r.LastIndex && last >= r.FirstIndex
+}
+
+// Determine if the reservation reserves a name.
+func (r *Reservation) ReservesName(name string) bool {
+	return r.Name != "" && r.Name == name
+}
+
+// Reservation list.
+//
+// Shared by declarations carrying indexed fields or arguments.
+type ReservationList struct {
+	// Reservations.
+	Reservations []*Reservation
+}
+
+// Add a reservation of a range of indexes.
+func (l *ReservationList) AddIndexReservation(first, last uint) *Reservation {
+	reservation := &Reservation{
+		FirstIndex: first,
+		LastIndex:  last,
+	}
+
+	l.Reservations = append(l.Reservations, reservation)
+
+	return reservation
+}
+
+// Add a reservation of a name.
+func (l *ReservationList) AddNameReservation(name string) *Reservation {
+	reservation := &Reservation{
+		Name: name,
+	}
+
+	l.Reservations = append(l.Reservations, reservation)
+
+	return reservation
+}
+
+// Reservation of any index of a range of indexes.
+//
+// Returns nil if none of the indexes are reserved.
+func (l *ReservationList) IndexReservation(first, last uint) *Reservation {
+	for _, r := range l.Reservations {
+		if r.ReservesIndexes(first, last) {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// Reservation of a name.
+//
+// Returns nil if the name is not reserved.
+func (l *ReservationList) NameReservation(name string) *Reservation {
+	for _, r := range l.Reservations {
+		if r.ReservesName(name) {
+			return r
+		}
+	}
+
+	return nil
+}
@@ -14,9 +14,17 @@ type Service struct {
 	// Empty if the service does not inherit from a parent.
 	ParentName string
 
+	// Parent service.
+	//
+	// Nil if the service does not inherit from a parent.
+	Parent *Service
+
 	// Documentation paragraphs.
 	Documentation []string
 
+	// Annotations.
+	Annotations Annotations
+
 	// Functions.
 	//
 	// Do not modify this slice directly. Always use AddFunction.
@@ -39,10 +47,20 @@ func NewService(name string, documentation []string) *Service {
 
 // Add a function to a service declaration.
 //
-// The caller is expected to have validated that neither the name nor index are
-// in use before calling AddFunction.
+// The caller is expected to have validated that the name is not in use
+// before calling AddFunction, unless the function redeclares an inherited
+// function, in which case the inherited function is replaced.
 func (s *Service) AddFunction(function *Function) {
-	s.Functions = append(s.Functions, function)
+	if existing, found := s.functionNameMapping[function.Name]; found {
+		for i, f := range s.Functions {
+			if f == existing {
+				s.Functions[i] = function
+			}
+		}
+	} else {
+		s.Functions = append(s.Functions, function)
+	}
+
 	s.functionNameMapping[function.Name] = function
 }
 
@@ -50,10 +68,45 @@ func (s *Service) AddFunction(function *Function) {
 func (s *Service) Inherit(name string, documentation []string) *Service {
 	c := NewService(name, documentation)
 	c.ParentName = s.Name
+	c.Parent = s
+
+	for _, f := range s.Functions {
+		c.AddFunction(f)
+	}
 
 	return c
 }
 
+// Get a function by name.
+func (s *Service) Function(name string) (function *Function, found bool) {
+	function, found = s.functionNameMapping[name]
+	return
+}
+
+// Determine if a function is inherited from the parent service.
+//
+// Functions redeclared with the same signature are considered inherited.
+func (s *Service) FunctionInherited(name string) bool {
+	return s.Parent != nil && s.Parent.FunctionNameInUse(name)
+}
+
+// Sorted list of functions declared by the service itself by name.
+//
+// Excludes functions inherited from the parent service.
+func (s *Service) DeclaredFunctionsSortedByName() []*Function {
+	declared := make([]*Function, 0, len(s.Functions))
+
+	for _, f := range s.Functions {
+		if !s.FunctionInherited(f.Name) {
+			declared = append(declared, f)
+		}
+	}
+
+	sort.Sort(functionsByName(declared))
+
+	return declared
+}
+
 // Determine if a function name is in use.
 func (s *Service) FunctionNameInUse(name string) bool {
 	_, inUse := s.functionNameMapping[name]
@@ -74,3 +127,18 @@ func (s *Service) FunctionsSortedByName() []*Function {
 
 	return unsorted
 }
+
+// Services by name.
+type servicesByName []*Service
+
+func (l servicesByName) Len() int {
+	return len(l)
+}
+
+func (l servicesByName) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+func (l servicesByName) Less(i, j int) bool {
+	return l[i].Name < l[j].Name
+}
@@ -1,8 +1,17 @@
 package declarations
 
-import (
-	"sort"
-)
+// Name of the annotation marking structs as patches.
+//
+// The presence of each field of a patch is tracked, so that absent fields can
+// be told apart from fields set to nil.
+const PatchAnnotation = "patch"
+
+// Name of the annotation marking struct hierarchies as polymorphic.
+//
+// Values of structs in a polymorphic hierarchy can hold the struct or any
+// struct inheriting from it, and are serialized along with the name of the
+// struct as a discriminator.
+const PolymorphicAnnotation = "polymorphic"
 
 // Struct declaration.
 type Struct struct {
@@ -14,111 +23,110 @@ type Struct struct {
 	// Empty if the struct does not inherit from a parent.
 	ParentName string
 
+	// Parent struct.
+	//
+	// Nil if the struct does not inherit from a parent.
+	Parent *Struct
+
 	// Documentation paragraphs.
 	Documentation []string
 
-	// Fields.
-	//
-	// Do not modify this slice directly. Always use AddField.
-	Fields []*Field
+	// Annotations.
+	Annotations Annotations
 
-	// Field name mapping.
-	fieldNameMapping map[string]*Field
+	// Fields.
+	FieldList
 
-	// Field index mapping.
-	fieldIndexMapping map[uint]*Field
+	// Structs inheriting from the struct.
+	children []*Struct
 }
 
 // New struct declaration.
 func NewStruct(name string, documentation []string) *Struct {
 	return &Struct{
-		Name:              name,
-		ParentName:        "",
-		Documentation:     documentation,
-		Fields:            []*Field{},
-		fieldNameMapping:  map[string]*Field{},
-		fieldIndexMapping: map[uint]*Field{},
-	}
-}
-
-// Add a field to a struct declaration.
-//
-// The caller is expected to have validated that neither the name nor index are
-// in use before calling AddField.
-func (s *Struct) AddField(index uint, name string, documentation []string, fieldType Type) {
-	field := &Field{
-		Index:         index,
 		Name:          name,
+		ParentName:    "",
 		Documentation: documentation,
-		Type:          fieldType,
+		FieldList:     newFieldList(),
 	}
-
-	s.Fields = append(s.Fields, field)
-	s.fieldNameMapping[name] = field
-	s.fieldIndexMapping[index] = field
 }
 
-// Inherit from the current struct to a new struct.
-func (s *Struct) Inherit(name string, documentation []string) *Struct {
-	c := NewStruct(name, documentation)
-	c.ParentName = s.Name
+// Determine if the struct is a patch.
+func (s *Struct) Patch() bool {
+	return s.Annotations.Has(PatchAnnotation)
+}
 
-	for _, f := range s.Fields {
-		c.AddField(f.Index, f.Name, f.Documentation, f.Type)
+// Determine if the struct is part of a polymorphic hierarchy.
+func (s *Struct) Polymorphic() bool {
+	for a := s; a != nil; a = a.Parent {
+		if a.Annotations.Has(PolymorphicAnnotation) {
+			return true
+		}
 	}
 
-	return c
+	return false
 }
 
-// Determine if a field index is in use.
-func (s *Struct) FieldIndexInUse(index uint) bool {
-	_, inUse := s.fieldIndexMapping[index]
-	return inUse
+// Determine if the struct preserves unknown elements.
+//
+// Trailing elements of a serialized struct unknown to the receiver are kept
+// when deserializing the struct and re-emitted when serializing it. All
+// structs but patches, which are serialized as maps, preserve unknown
+// elements.
+func (s *Struct) PreservesUnknown() bool {
+	return !s.Patch()
 }
 
-// Determine if a field name is in use.
-func (s *Struct) FieldNameInUse(name string) bool {
-	_, inUse := s.fieldNameMapping[name]
-	return inUse
+// Ancestors of the struct, nearest first.
+func (s *Struct) Ancestors() []*Struct {
+	var ancestors []*Struct
+	for a := s.Parent; a != nil; a = a.Parent {
+		ancestors = append(ancestors, a)
+	}
+	return ancestors
 }
 
-// Sorted list of fields by index.
-func (s *Struct) FieldsSortedByIndex() []*Field {
-	unsorted := make([]*Field, len(s.Fields))
-
-	idx := 0
-	for _, exc := range s.Fields {
-		unsorted[idx] = exc
-		idx++
+// Descendants of the struct in order of declaration.
+//
+// Children are listed after their parent.
+func (s *Struct) Descendants() []*Struct {
+	var descendants []*Struct
+	for _, c := range s.children {
+		descendants = append(descendants, c)
+		descendants = append(descendants, c.Descendants()...)
 	}
-
-	sort.Sort(fieldsByIndex(unsorted))
-
-	return unsorted
+	return descendants
 }
 
-// Minimum length of deserialized array.
-func (s *Struct) MinimumDeserializedLength() (minimum int) {
-	minIndex := uint(0)
+// Inherit from the current struct to a new struct.
+//
+// The field declarations and reservations are shared with the new struct.
+func (s *Struct) Inherit(name string, documentation []string) *Struct {
+	c := NewStruct(name, documentation)
+	c.ParentName = s.Name
+	c.Parent = s
+	s.children = append(s.children, c)
 
-	for _, field := range s.Fields {
-		if !field.Type.Nilable() && minIndex < field.Index {
-			minIndex = field.Index
-		}
+	for _, f := range s.Fields {
+		c.addField(f)
 	}
 
-	return int(minIndex)
+	c.Reservations = append(c.Reservations, s.Reservations...)
+
+	return c
 }
 
-// Length of serialized array.
-func (s *Struct) SerializedLength() (length int) {
-	maxIndex := uint(0)
+// Structs by name.
+type structsByName []*Struct
 
-	for _, field := range s.Fields {
-		if field.Index > maxIndex {
-			maxIndex = field.Index
-		}
-	}
+func (l structsByName) Len() int {
+	return len(l)
+}
+
+func (l structsByName) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
 
-	return int(maxIndex)
+func (l structsByName) Less(i, j int) bool {
+	return l[i].Name < l[j].Name
 }
@@ -18,10 +18,15 @@ const (
 	Uint16Class
 	Uint32Class
 	Uint64Class
+	TimestampClass
+	DurationClass
 	EnumClass
 	StructClass
 	MapClass
 	ListClass
+	SetClass
+	TypedefClass
+	UnionClass
 )
 
 // Type declaration.
@@ -52,6 +57,7 @@ func (s *simpleType) Nilable() bool {
 // Struct type.
 type StructType struct {
 	decl    *Struct
+	imp     *Import
 	nilable bool
 }
 
@@ -67,6 +73,13 @@ func (s *StructType) Struct() *Struct {
 	return s.decl
 }
 
+// Import the struct is declared in.
+//
+// Nil if the struct is declared in the referencing interface.
+func (s *StructType) Import() *Import {
+	return s.imp
+}
+
 // New struct type.
 func NewStructType(decl *Struct, nilable bool) Type {
 	return &StructType{
@@ -75,9 +88,19 @@ func NewStructType(decl *Struct, nilable bool) Type {
 	}
 }
 
+// New struct type for a struct declared in an imported interface.
+func NewImportedStructType(imp *Import, decl *Struct, nilable bool) Type {
+	return &StructType{
+		decl:    decl,
+		imp:     imp,
+		nilable: nilable,
+	}
+}
+
 // Enum type.
 type EnumType struct {
 	decl    *Enum
+	imp     *Import
 	nilable bool
 }
 
@@ -93,6 +116,13 @@ func (s *EnumType) Enum() *Enum {
 	return s.decl
 }
 
+// Import the enum is declared in.
+//
+// Nil if the enum is declared in the referencing interface.
+func (s *EnumType) Import() *Import {
+	return s.imp
+}
+
 // New enum type.
 func NewEnumType(decl *Enum, nilable bool) Type {
 	return &EnumType{
@@ -101,6 +131,58 @@ func NewEnumType(decl *Enum, nilable bool) Type {
 	}
 }
 
+// New enum type for an enum declared in an imported interface.
+func NewImportedEnumType(imp *Import, decl *Enum, nilable bool) Type {
+	return &EnumType{
+		decl:    decl,
+		imp:     imp,
+		nilable: nilable,
+	}
+}
+
+// Union type.
+type UnionType struct {
+	decl    *Union
+	imp     *Import
+	nilable bool
+}
+
+func (s *UnionType) Class() TypeClass {
+	return UnionClass
+}
+
+func (s *UnionType) Nilable() bool {
+	return s.nilable
+}
+
+func (s *UnionType) Union() *Union {
+	return s.decl
+}
+
+// Import the union is declared in.
+//
+// Nil if the union is declared in the referencing interface.
+func (s *UnionType) Import() *Import {
+	return s.imp
+}
+
+// New union type.
+func NewUnionType(decl *Union, nilable bool) Type {
+	return &UnionType{
+		decl:    decl,
+		nilable: nilable,
+	}
+}
+
+// New union type for a union declared in an imported interface.
+func NewImportedUnionType(imp *Import, decl *Union, nilable bool) Type {
+	return &UnionType{
+		decl:    decl,
+		imp:     imp,
+		nilable: nilable,
+	}
+}
+
 // List type.
 type ListType struct {
 	elementType Type
@@ -127,6 +209,32 @@ func NewListType(elementType Type, nilable bool) Type {
 	}
 }
 
+// Set type.
+type SetType struct {
+	elementType Type
+	nilable     bool
+}
+
+func (s *SetType) Class() TypeClass {
+	return SetClass
+}
+
+func (s *SetType) ElementType() Type {
+	return s.elementType
+}
+
+func (s *SetType) Nilable() bool {
+	return s.nilable
+}
+
+// New set type.
+func NewSetType(elementType Type, nilable bool) Type {
+	return &SetType{
+		elementType: elementType,
+		nilable:     nilable,
+	}
+}
+
 // Map type.
 type MapType struct {
 	keyType   Type
@@ -159,31 +267,182 @@ func NewMapType(keyType, valueType Type, nilable bool) Type {
 	}
 }
 
+// Type alias type.
+type TypedefType struct {
+	decl    *Typedef
+	imp     *Import
+	nilable bool
+}
+
+func (s *TypedefType) Class() TypeClass {
+	return TypedefClass
+}
+
+func (s *TypedefType) Nilable() bool {
+	return s.nilable
+}
+
+func (s *TypedefType) Typedef() *Typedef {
+	return s.decl
+}
+
+// Import through which the type alias is declared.
+//
+// Nil if the type alias is declared in the same interface.
+func (s *TypedefType) Import() *Import {
+	return s.imp
+}
+
+// Underlying type of the alias.
+//
+// The nilability of the alias is applied to the aliased type, and types
+// declared in an imported interface are qualified by the import.
+func (s *TypedefType) Underlying() Type {
+	return qualifiedType(s.decl.Type, s.imp, s.nilable)
+}
+
+// New type alias type.
+func NewTypedefType(decl *Typedef, nilable bool) Type {
+	return &TypedefType{
+		decl:    decl,
+		nilable: nilable,
+	}
+}
+
+// New type alias type for a type alias declared in an imported interface.
+func NewImportedTypedefType(imp *Import, decl *Typedef, nilable bool) Type {
+	return &TypedefType{
+		decl:    decl,
+		imp:     imp,
+		nilable: nilable,
+	}
+}
+
+// Resolve type aliases to the underlying type.
+func UnderlyingType(typeDecl Type) Type {
+	for {
+		typedefTypeDecl, ok := typeDecl.(*TypedefType)
+		if !ok {
+			return typeDecl
+		}
+
+		typeDecl = typedefTypeDecl.Underlying()
+	}
+}
+
+// Determine if two types are the same.
+func SameType(a, b Type) bool {
+	if a.Class() != b.Class() || a.Nilable() != b.Nilable() {
+		return false
+	}
+
+	switch a := a.(type) {
+	case *StructType:
+		return a.decl == b.(*StructType).decl
+
+	case *EnumType:
+		return a.decl == b.(*EnumType).decl
+
+	case *TypedefType:
+		return a.decl == b.(*TypedefType).decl
+
+	case *UnionType:
+		return a.decl == b.(*UnionType).decl
+
+	case *ListType:
+		return SameType(a.elementType, b.(*ListType).elementType)
+
+	case *SetType:
+		return SameType(a.elementType, b.(*SetType).elementType)
+
+	case *MapType:
+		bMap := b.(*MapType)
+		return SameType(a.keyType, bMap.keyType) && SameType(a.valueType, bMap.valueType)
+
+	default:
+		return true
+	}
+}
+
+// Qualify a type with an import and nilability.
+//
+// Struct, enum, union and type alias types not declared through an import are
+// qualified by the import if one is given.
+func qualifiedType(typeDecl Type, imp *Import, nilable bool) Type {
+	switch t := typeDecl.(type) {
+	case *simpleType:
+		if t.nilable == nilable {
+			return t
+		}
+		return &simpleType{t.class, nilable}
+
+	case *StructType:
+		if t.imp != nil {
+			imp = t.imp
+		}
+		return &StructType{t.decl, imp, nilable}
+
+	case *EnumType:
+		if t.imp != nil {
+			imp = t.imp
+		}
+		return &EnumType{t.decl, imp, nilable}
+
+	case *TypedefType:
+		if t.imp != nil {
+			imp = t.imp
+		}
+		return &TypedefType{t.decl, imp, nilable}
+
+	case *UnionType:
+		if t.imp != nil {
+			imp = t.imp
+		}
+		return &UnionType{t.decl, imp, nilable}
+
+	case *ListType:
+		return &ListType{qualifiedType(t.elementType, imp, t.elementType.Nilable()), nilable}
+
+	case *SetType:
+		return &SetType{qualifiedType(t.elementType, imp, t.elementType.Nilable()), nilable}
+
+	case *MapType:
+		return &MapType{qualifiedType(t.keyType, imp, t.keyType.Nilable()), qualifiedType(t.valueType, imp, t.valueType.Nilable()), nilable}
+
+	default:
+		panic("Unimplemented type")
+	}
+}
+
 var (
-	BoolType           = &simpleType{BoolClass, false}
-	NilableBoolType    = &simpleType{BoolClass, true}
-	StringType         = &simpleType{StringClass, false}
-	NilableStringType  = &simpleType{StringClass, true}
-	BinaryType         = &simpleType{BinaryClass, false}
-	NilableBinaryType  = &simpleType{BinaryClass, true}
-	Float32Type        = &simpleType{Float32Class, false}
-	NilableFloat32Type = &simpleType{Float32Class, true}
-	Float64Type        = &simpleType{Float64Class, false}
-	NilableFloat64Type = &simpleType{Float64Class, true}
-	Int8Type           = &simpleType{Int8Class, false}
-	NilableInt8Type    = &simpleType{Int8Class, true}
-	Int16Type          = &simpleType{Int16Class, false}
-	NilableInt16Type   = &simpleType{Int16Class, true}
-	Int32Type          = &simpleType{Int32Class, false}
-	NilableInt32Type   = &simpleType{Int32Class, true}
-	Int64Type          = &simpleType{Int64Class, false}
-	NilableInt64Type   = &simpleType{Int64Class, true}
-	Uint8Type          = &simpleType{Uint8Class, false}
-	NilableUint8Type   = &simpleType{Uint8Class, true}
-	Uint16Type         = &simpleType{Uint16Class, false}
-	NilableUint16Type  = &simpleType{Uint16Class, true}
-	Uint32Type         = &simpleType{Uint32Class, false}
-	NilableUint32Type  = &simpleType{Uint32Class, true}
-	Uint64Type         = &simpleType{Uint64Class, false}
-	NilableUint64Type  = &simpleType{Uint64Class, true}
+	BoolType             = &simpleType{BoolClass, false}
+	NilableBoolType      = &simpleType{BoolClass, true}
+	StringType           = &simpleType{StringClass, false}
+	NilableStringType    = &simpleType{StringClass, true}
+	BinaryType           = &simpleType{BinaryClass, false}
+	NilableBinaryType    = &simpleType{BinaryClass, true}
+	Float32Type          = &simpleType{Float32Class, false}
+	NilableFloat32Type   = &simpleType{Float32Class, true}
+	Float64Type          = &simpleType{Float64Class, false}
+	NilableFloat64Type   = &simpleType{Float64Class, true}
+	Int8Type             = &simpleType{Int8Class, false}
+	NilableInt8Type      = &simpleType{Int8Class, true}
+	Int16Type            = &simpleType{Int16Class, false}
+	NilableInt16Type     = &simpleType{Int16Class, true}
+	Int32Type            = &simpleType{Int32Class, false}
+	NilableInt32Type     = &simpleType{Int32Class, true}
+	Int64Type            = &simpleType{Int64Class, false}
+	NilableInt64Type     = &simpleType{Int64Class, true}
+	Uint8Type            = &simpleType{Uint8Class, false}
+	NilableUint8Type     = &simpleType{Uint8Class, true}
+	Uint16Type           = &simpleType{Uint16Class, false}
+	NilableUint16Type    = &simpleType{Uint16Class, true}
+	Uint32Type           = &simpleType{Uint32Class, false}
+	NilableUint32Type    = &simpleType{Uint32Class, true}
+	Uint64Type           = &simpleType{Uint64Class, false}
+	NilableUint64Type    = &simpleType{Uint64Class, true}
+	TimestampType        = &simpleType{TimestampClass, false}
+	NilableTimestampType = &simpleType{TimestampClass, true}
+	DurationType         = &simpleType{DurationClass, false}
+	NilableDurationType  = &simpleType{DurationClass, true}
 )
@@ -0,0 +1,40 @@
+package declarations
+
+// Type alias declaration.
+type Typedef struct {
+	// Alias name.
+	Name string
+
+	// Documentation paragraphs.
+	Documentation []string
+
+	// Annotations.
+	Annotations Annotations
+
+	// Aliased type.
+	Type Type
+}
+
+// New type alias declaration.
+func NewTypedef(name string, documentation []string, aliasedType Type) *Typedef {
+	return &Typedef{
+		Name:          name,
+		Documentation: documentation,
+		Type:          aliasedType,
+	}
+}
+
+// Type aliases by name.
+type typedefsByName []*Typedef
+
+func (l typedefsByName) Len() int {
+	return len(l)
+}
+
+func (l typedefsByName) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+func (l typedefsByName) Less(i, j int) bool {
+	return l[i].Name < l[j].Name
+}
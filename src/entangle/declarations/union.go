@@ -0,0 +1,43 @@
+package declarations
+
+// Tagged union declaration.
+//
+// Members are indexed like struct fields. A union value holds exactly one of
+// its members and is serialized as an array of the member index and value.
+type Union struct {
+	// Union name.
+	Name string
+
+	// Documentation paragraphs.
+	Documentation []string
+
+	// Annotations.
+	Annotations Annotations
+
+	// Members.
+	FieldList
+}
+
+// New union declaration.
+func NewUnion(name string, documentation []string) *Union {
+	return &Union{
+		Name:          name,
+		Documentation: documentation,
+		FieldList:     newFieldList(),
+	}
+}
+
+// Unions by name.
+type unionsByName []*Union
+
+func (l unionsByName) Len() int {
+	return len(l)
+}
+
+func (l unionsByName) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+func (l unionsByName) Less(i, j int) bool {
+	return l[i].Name < l[j].Name
+}
@@ -17,6 +17,17 @@ type ParseErrorFrame struct {
 	End token.Position
 }
 
+// Parse error note.
+//
+// Points out a location related to an error, such as a previous declaration
+// conflicting with the erroneous one.
+type ParseErrorNote struct {
+	ParseErrorFrame
+
+	// Description.
+	Description string
+}
+
 // Parse error.
 type ParseError interface {
 	error
@@ -29,6 +40,9 @@ type ParseError interface {
 	// The last frame describes the actual error, while all previous frames
 	// are guaranteed to describe imports.
 	Frames() []ParseErrorFrame
+
+	// Notes.
+	Notes() []ParseErrorNote
 }
 
 // Parse error implementation.
@@ -36,6 +50,7 @@ type parseError struct {
 	src *source.Source
 	description string
 	frames []ParseErrorFrame
+	notes []ParseErrorNote
 }
 
 func (p *parseError) Source() *source.Source {
@@ -50,6 +65,10 @@ func (p *parseError) Frames() []ParseErrorFrame {
 	return p.frames
 }
 
+func (p *parseError) Notes() []ParseErrorNote {
+	return p.notes
+}
+
 func (p *parseError) Error() string {
 	return p.description
 }
@@ -89,3 +108,11 @@ func NewParseError(description string, start token.Position, end token.Position,
 
 	return err
 }
+
+// New parse error with notes.
+func NewParseErrorWithNotes(description string, start token.Position, end token.Position, notes []ParseErrorNote, src *source.Source, errorFrames []ParseErrorFrame) ParseError {
+	err := NewParseError(description, start, end, src, errorFrames).(*parseError)
+	err.notes = notes
+
+	return err
+}
@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"fmt"
+)
+
+// Parse error list.
+//
+// Errors are in the order they were encountered in. The list itself is a
+// parse error describing the first error of the list, and is never empty.
+type ParseErrorList []ParseError
+
+func (l ParseErrorList) Description() string {
+	return l[0].Description()
+}
+
+func (l ParseErrorList) Frames() []ParseErrorFrame {
+	return l[0].Frames()
+}
+
+func (l ParseErrorList) Notes() []ParseErrorNote {
+	return l[0].Notes()
+}
+
+func (l ParseErrorList) Error() string {
+	if len(l) == 1 {
+		return l[0].Error()
+	}
+
+	return fmt.Sprintf("%s (and %d more errors)", l[0].Error(), len(l)-1)
+}
+
+// Parse errors of an error.
+//
+// Flattens parse error lists into their errors.
+func ParseErrors(err ParseError) []ParseError {
+	list, ok := err.(ParseErrorList)
+	if !ok {
+		return []ParseError{err}
+	}
+
+	errs := make([]ParseError, 0, len(list))
+	for _, e := range list {
+		errs = append(errs, ParseErrors(e)...)
+	}
+
+	return errs
+}
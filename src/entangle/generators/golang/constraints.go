@@ -0,0 +1,167 @@
+package golang
+
+import (
+	"entangle/declarations"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Pattern of a value constraint.
+type constraintPattern struct {
+	// Name of the variable holding the compiled pattern.
+	Variable string
+
+	// Regular expression.
+	Pattern string
+}
+
+// Name of the variable holding a compiled constraint pattern.
+//
+// The name is derived from the pattern, so that the variable can be referenced
+// by any check of the pattern.
+func patternVariableName(pattern string) string {
+	h := fnv.New32a()
+	h.Write([]byte(pattern))
+	return fmt.Sprintf("pattern%08x", h.Sum32())
+}
+
+// Build the list of constraint patterns declared in an interface.
+//
+// Patterns are ordered by variable name.
+func buildConstraintPatterns(interfaceDecl *declarations.Interface) []constraintPattern {
+	patterns := make(map[string]string)
+
+	add := func(annotations declarations.Annotations) {
+		if pattern := annotations.Constraints().Pattern; pattern != "" {
+			patterns[patternVariableName(pattern)] = pattern
+		}
+	}
+
+	fieldLists := make([]*declarations.FieldList, 0, len(interfaceDecl.Structs)+len(interfaceDecl.Exceptions)+len(interfaceDecl.Unions))
+	for _, structDecl := range interfaceDecl.Structs {
+		fieldLists = append(fieldLists, &structDecl.FieldList)
+	}
+	for _, excDecl := range interfaceDecl.Exceptions {
+		fieldLists = append(fieldLists, &excDecl.FieldList)
+	}
+	for _, unionDecl := range interfaceDecl.Unions {
+		fieldLists = append(fieldLists, &unionDecl.FieldList)
+	}
+
+	for _, fieldList := range fieldLists {
+		for _, field := range fieldList.Fields {
+			add(field.Annotations)
+		}
+	}
+
+	for _, serviceDecl := range interfaceDecl.Services {
+		for _, functionDecl := range serviceDecl.Functions {
+			for _, arg := range functionDecl.Arguments {
+				add(arg.Annotations)
+			}
+		}
+	}
+
+	variables := make([]string, 0, len(patterns))
+	for variable := range patterns {
+		variables = append(variables, variable)
+	}
+	sort.Strings(variables)
+
+	list := make([]constraintPattern, len(variables))
+	for i, variable := range variables {
+		list[i] = constraintPattern{
+			Variable: variable,
+			Pattern:  patterns[variable],
+		}
+	}
+
+	return list
+}
+
+// Code checking the constraints declared for a value.
+//
+// Violations are reported by assigning an error to the named error variable
+// and returning. Errors are bad message errors if badMessage is set, as used
+// when checking received values. Empty if no constraints are declared.
+func constraintCheckCodeHelper(typeDecl declarations.Type, annotations declarations.Annotations, source, desc, errName string, badMessage bool, indentation int) string {
+	constraints := annotations.Constraints()
+	if !constraints.Any() {
+		return ""
+	}
+
+	class := declarations.UnderlyingType(typeDecl).Class()
+
+	// Nilable values are represented by pointers, except for binary values,
+	// lists and maps, which are nilable themselves.
+	pointer := strings.HasPrefix(typeHelper(typeDecl), "*")
+	value := source
+	if pointer {
+		value = fmt.Sprintf("*%s", source)
+	}
+
+	length := fmt.Sprintf("len(%s)", value)
+	if class == declarations.StringClass {
+		if typeDecl.Class() == declarations.TypedefClass {
+			length = fmt.Sprintf("utf8.RuneCountInString(string(%s))", value)
+		} else {
+			length = fmt.Sprintf("utf8.RuneCountInString(%s)", value)
+		}
+	}
+
+	checks := make([]string, 0, 4)
+	check := func(condition, description string) {
+		newError := fmt.Sprintf("errors.New(%s)", strconv.Quote(description))
+		if badMessage {
+			newError = fmt.Sprintf("goentangle.BadMessageError.New(%s)", strconv.Quote(description))
+		}
+
+		checks = append(checks, fmt.Sprintf(`if %s {
+	%s = %s
+	return
+}`, condition, errName, newError))
+	}
+
+	// Lower bounds of zero are implied for unsigned integers and lengths.
+	unsigned := class == declarations.Uint8Class || class == declarations.Uint16Class || class == declarations.Uint32Class || class == declarations.Uint64Class
+
+	if constraints.Min != nil && !(unsigned && valueHelper(nil, constraints.Min) == "0") {
+		check(fmt.Sprintf("%s < %s", value, valueHelper(nil, constraints.Min)), fmt.Sprintf("value of %s must be at least %s", desc, valueHelper(nil, constraints.Min)))
+	}
+
+	if constraints.Max != nil {
+		check(fmt.Sprintf("%s > %s", value, valueHelper(nil, constraints.Max)), fmt.Sprintf("value of %s must be at most %s", desc, valueHelper(nil, constraints.Max)))
+	}
+
+	if constraints.MinLength != nil && constraints.MinLength.(uint64) > 0 {
+		check(fmt.Sprintf("%s < %d", length, constraints.MinLength), fmt.Sprintf("length of %s must be at least %d", desc, constraints.MinLength))
+	}
+
+	if constraints.MaxLength != nil {
+		check(fmt.Sprintf("%s > %d", length, constraints.MaxLength), fmt.Sprintf("length of %s must be at most %d", desc, constraints.MaxLength))
+	}
+
+	if constraints.Pattern != "" {
+		str := value
+		if typeDecl.Class() == declarations.TypedefClass {
+			str = fmt.Sprintf("string(%s)", value)
+		}
+
+		check(fmt.Sprintf("!%s.MatchString(%s)", patternVariableName(constraints.Pattern), str), fmt.Sprintf("%s must match the pattern %q", desc, constraints.Pattern))
+	}
+
+	if constraints.NonEmpty {
+		check(fmt.Sprintf("len(%s) == 0", value), fmt.Sprintf("%s must not be empty", desc))
+	}
+
+	code := strings.Join(checks, "\n\n")
+
+	if pointer {
+		code = fmt.Sprintf("if %s != nil {\n%s\n}", source, indent(code, 1))
+	}
+
+	return indent(code, indentation)
+}
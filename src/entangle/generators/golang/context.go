@@ -4,6 +4,18 @@ import (
 	"entangle/declarations"
 )
 
+// Package import.
+type packageImport struct {
+	// Package name.
+	Name string
+
+	// Import path.
+	Path string
+
+	// Name of the imported definition.
+	Definition string
+}
+
 // Template context.
 type context struct {
 	// Interface definition.
@@ -12,6 +24,23 @@ type context struct {
 	// Serialization/deserialization mapping.
 	SerDesMap map[string]declarations.Type
 
+	// Serialization mapping.
+	Serializers map[string]declarations.Type
+
+	// Set types.
+	Sets []*declarations.SetType
+
+	// Patterns of value constraints.
+	Patterns []constraintPattern
+
 	// Package name.
 	PackageName string
+
+	// Imports of packages generated from imported definitions.
+	Imports []packageImport
+
+	// Imports of packages parsing the exceptions of imported definitions.
+	//
+	// Holds a single import per imported definition declaring exceptions.
+	ExceptionImports []packageImport
 }
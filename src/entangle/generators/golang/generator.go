@@ -8,6 +8,7 @@ import (
 	"entangle/generators"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"text/template"
@@ -16,11 +17,16 @@ import (
 // Go generator.
 type generator struct {
 	options                    *Options
+	constantsTmpl              *template.Template
+	constraintsTmpl            *template.Template
+	setsTmpl                   *template.Template
+	typedefsTmpl               *template.Template
 	exceptionsTmpl             *template.Template
 	servicesTmpl               *template.Template
 	serviceImplementationsTmpl *template.Template
 	enumsTmpl                  *template.Template
 	structsTmpl                *template.Template
+	unionsTmpl                 *template.Template
 	deserializationTmpl        *template.Template
 	serializationTmpl          *template.Template
 	serversTmpl                *template.Template
@@ -35,15 +41,28 @@ func NewGenerator(options *Options) (gen generators.Generator, err error) {
 
 	// Define function mapping.
 	funcMap := template.FuncMap{
-		"documentation":             documentationHelper,
-		"type":                      typeHelper,
-		"nonNilableType":            nonNilableTypeHelper,
-		"canSkipBeforeField":        canSkipBeforeFieldHelper,
-		"deserializationCode":       deserializationCodeHelper,
-		"serializationCode":         serializationCodeHelper,
-		"structSerializationCode":   structSerializationCodeHelper,
-		"typeDeserializationMethod": typeDeserializationMethodHelper,
-		"typeSerializationCode":     typeSerializationCodeHelper,
+		"documentation":              documentationHelper,
+		"declarationDocumentation":   declarationDocumentationHelper,
+		"deprecation":                deprecationHelper,
+		"functionDocumentation":      functionDocumentationHelper,
+		"type":                       typeHelper,
+		"nonNilableType":             nonNilableTypeHelper,
+		"value":                      valueHelper,
+		"constants":                  constantsHelper,
+		"duration":                   durationHelper,
+		"canSkipBeforeField":         canSkipBeforeFieldHelper,
+		"constraintCheck":            constraintCheckCodeHelper,
+		"deserializationCode":        deserializationCodeHelper,
+		"serializationCode":          serializationCodeHelper,
+		"structSerializationCode":    structSerializationCodeHelper,
+		"patchSerializationCode":     patchSerializationCodeHelper,
+		"patchDeserializationCode":   patchDeserializationCodeHelper,
+		"patchFieldName":             patchFieldNameHelper,
+		"patchGetterDocumentation":   patchGetterDocumentationHelper,
+		"patchAccessorDocumentation": patchAccessorDocumentationHelper,
+		"typeDeserializationMethod":  typeDeserializationMethodHelper,
+		"typeSerializationCode":      typeSerializationCodeHelper,
+		"interfaceType":              interfaceTypeHelper,
 		"fieldIndex": func(fieldDecl *declarations.Field) string {
 			return fmt.Sprintf("%d", fieldDecl.Index-1)
 		},
@@ -66,11 +85,16 @@ func NewGenerator(options *Options) (gen generators.Generator, err error) {
 		Filename string
 		Target   **template.Template
 	}{
+		{"constants.go.tmpl", &g.constantsTmpl},
+		{"constraints.go.tmpl", &g.constraintsTmpl},
+		{"sets.go.tmpl", &g.setsTmpl},
 		{"exceptions.go.tmpl", &g.exceptionsTmpl},
 		{"services.go.tmpl", &g.servicesTmpl},
 		{"service_implementations.go.tmpl", &g.serviceImplementationsTmpl},
 		{"enums.go.tmpl", &g.enumsTmpl},
+		{"typedefs.go.tmpl", &g.typedefsTmpl},
 		{"structs.go.tmpl", &g.structsTmpl},
+		{"unions.go.tmpl", &g.unionsTmpl},
 		{"deserialization.go.tmpl", &g.deserializationTmpl},
 		{"serialization.go.tmpl", &g.serializationTmpl},
 		{"servers.go.tmpl", &g.serversTmpl},
@@ -101,11 +125,37 @@ func (g *generator) Generate(interfaceDecl *declarations.Interface, outputPath s
 	// Build a serialization/deserialization map for helper functions.
 	serDesMap := buildSerDesMap(interfaceDecl)
 
+	// Build the imports of packages generated from imported definitions.
+	packageImports := make([]packageImport, 0, len(interfaceDecl.Imports))
+
+	exceptionImports := make([]packageImport, 0, len(interfaceDecl.Imports))
+	exceptionDefinitions := make(map[string]bool, len(interfaceDecl.Imports))
+
+	for _, importDecl := range interfaceDecl.ImportsSortedByName() {
+		imp := packageImport{
+			Name:       importDecl.Name,
+			Path:       path.Join(g.options.ImportPrefix, importDecl.Interface.Name),
+			Definition: importDecl.Interface.Name,
+		}
+
+		packageImports = append(packageImports, imp)
+
+		if len(importDecl.Interface.Exceptions) > 0 && imp.Definition != interfaceDecl.Name && !exceptionDefinitions[imp.Definition] {
+			exceptionImports = append(exceptionImports, imp)
+			exceptionDefinitions[imp.Definition] = true
+		}
+	}
+
 	// Set up the context.
 	ctx := &context{
-		Interface:   interfaceDecl,
-		SerDesMap:   serDesMap,
-		PackageName: interfaceDecl.Name,
+		Interface:        interfaceDecl,
+		SerDesMap:        serDesMap,
+		Serializers:      buildSerializers(serDesMap),
+		Sets:             buildSets(serDesMap),
+		Patterns:         buildConstraintPatterns(interfaceDecl),
+		PackageName:      interfaceDecl.Name,
+		Imports:          packageImports,
+		ExceptionImports: exceptionImports,
 	}
 
 	// Generate output files.
@@ -113,11 +163,16 @@ func (g *generator) Generate(interfaceDecl *declarations.Interface, outputPath s
 		Filename string
 		Template *template.Template
 	}{
+		{"constants.go", g.constantsTmpl},
+		{"constraints.go", g.constraintsTmpl},
+		{"sets.go", g.setsTmpl},
 		{"exceptions.go", g.exceptionsTmpl},
 		{"services.go", g.servicesTmpl},
 		{"service_implementations.go", g.serviceImplementationsTmpl},
 		{"enums.go", g.enumsTmpl},
+		{"typedefs.go", g.typedefsTmpl},
 		{"structs.go", g.structsTmpl},
+		{"unions.go", g.unionsTmpl},
 		{"deserialization.go", g.deserializationTmpl},
 		{"serialization.go", g.serializationTmpl},
 		{"servers.go", g.serversTmpl},
@@ -1,4 +1,10 @@
 package golang
 
 // Generator options.
-type Options struct{}
+type Options struct {
+	// Import path prefix.
+	//
+	// Packages generated from imported definitions are imported as
+	// <prefix>/<definition name>.
+	ImportPrefix string
+}
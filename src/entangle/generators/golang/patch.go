@@ -0,0 +1,158 @@
+package golang
+
+import (
+	"entangle/declarations"
+	"fmt"
+	gotoken "go/token"
+	"strings"
+)
+
+// Name of the unexported struct field holding the value of a patch field.
+//
+// Values of patch fields are only accessible through accessors, so that
+// setting a field always marks it as present. Names colliding with Go
+// keywords are suffixed by an underscore.
+func patchFieldNameHelper(field *declarations.Field) string {
+	name := strings.ToLower(field.Name[:1]) + field.Name[1:]
+	if gotoken.Lookup(name).IsKeyword() {
+		name += "_"
+	}
+
+	return name
+}
+
+// Documentation of the getter of a patch field.
+func patchGetterDocumentationHelper(field *declarations.Field) string {
+	documentation := make([]string, 0, len(field.Documentation)+2)
+	if len(field.Documentation) == 0 {
+		documentation = append(documentation, fmt.Sprintf("Value of %s.", field.Name))
+	}
+	documentation = append(documentation, field.Documentation...)
+	documentation = append(documentation, "The zero value if absent.")
+
+	return declarationDocumentationHelper(documentation, field.Annotations, 0)
+}
+
+// Documentation of an accessor of a patch field.
+//
+// Accessors of deprecated fields are marked as deprecated.
+func patchAccessorDocumentationHelper(field *declarations.Field, summary string) string {
+	return declarationDocumentationHelper([]string{summary}, field.Annotations, 0)
+}
+
+// Code serializing a patch.
+//
+// Patches are serialized as maps of field indexes to values, from which absent
+// fields are left out.
+func patchSerializationCodeHelper(structDecl *declarations.Struct) string {
+	parts := make([]string, 0, 2+len(structDecl.Fields))
+
+	parts = append(parts, fmt.Sprintf(`	serMap := make(map[interface{}]interface{}, %d)`, len(structDecl.Fields)))
+
+	for _, field := range structDecl.FieldsSortedByIndex() {
+		body := make([]string, 0, 3)
+
+		if check := constraintCheckCodeHelper(field.Type, field.Annotations, fmt.Sprintf("s.%s", patchFieldNameHelper(field)), fmt.Sprintf("field %s in %s", field.Name, structDecl.Name), "err", false, 2); check != "" {
+			body = append(body, check)
+		}
+
+		body = append(body, fmt.Sprintf(`		var serValue interface{}
+%s`, typeSerializationCodeHelper(field.Type, fmt.Sprintf("s.%s", patchFieldNameHelper(field)), "serValue", "err", 2)))
+
+		body = append(body, fmt.Sprintf(`		serMap[uint64(%d)] = serValue`, field.Index))
+
+		parts = append(parts, fmt.Sprintf(`	// Serialize %s.
+	if s.has%s {
+%s
+	}`, field.Name, field.Name, strings.Join(body, "\n\n")))
+	}
+
+	parts = append(parts, `	ser = serMap
+	return`)
+
+	return strings.Join(parts, "\n\n")
+}
+
+// Code deserializing a patch.
+//
+// Fields present in the serialized map are marked as present. Fields unknown
+// to the receiver are ignored, and default values are not applied to absent
+// fields.
+func patchDeserializationCodeHelper(structDecl *declarations.Struct) string {
+	cases := make([]string, 0, len(structDecl.Fields))
+
+	// Values are only deserialized if the patch has any fields.
+	serValue := ""
+	if len(structDecl.Fields) > 0 {
+		serValue = ", serValue"
+	}
+
+	for _, field := range structDecl.FieldsSortedByIndex() {
+		var code string
+
+		if field.Type.Nilable() {
+			// Binary values, lists and maps are nilable without being
+			// pointers.
+			assignment := fmt.Sprintf("des.%s = desValue", patchFieldNameHelper(field))
+			if strings.HasPrefix(typeHelper(field.Type), "*") {
+				assignment = fmt.Sprintf("des.%s = &desValue", patchFieldNameHelper(field))
+			}
+
+			code = fmt.Sprintf(`if serValue != nil {
+	var desErr error
+	var desValue %s
+	if desValue, desErr = %s(serValue); desErr != nil {
+		if desErr == goentangle.ErrDeserializationError {
+			err = errors.New("invalid value for field %s in %s")
+		} else {
+			err = desErr
+		}
+		return
+	}
+
+	%s
+}`, nonNilableTypeHelper(field.Type), typeDeserializationMethodHelper(field.Type), field.Name, structDecl.Name, assignment)
+		} else {
+			code = fmt.Sprintf(`if serValue == nil {
+	err = errors.New("%s in %s cannot be nil")
+	return
+} else if des.%s, err = %s(serValue); err != nil {
+	if err == goentangle.ErrDeserializationError {
+		err = errors.New("invalid value for field %s in %s")
+	}
+	return
+}`, field.Name, structDecl.Name, patchFieldNameHelper(field), typeDeserializationMethodHelper(field.Type), field.Name, structDecl.Name)
+		}
+
+		if check := constraintCheckCodeHelper(field.Type, field.Annotations, fmt.Sprintf("des.%s", patchFieldNameHelper(field)), fmt.Sprintf("field %s in %s", field.Name, structDecl.Name), "err", true, 0); check != "" {
+			code = fmt.Sprintf("%s\n\n%s", code, check)
+		}
+
+		cases = append(cases, fmt.Sprintf(`		case %d:
+%s
+
+			des.has%s = true`, field.Index, indent(code, 3), field.Name))
+	}
+
+	return fmt.Sprintf(`	var ser map[interface{}]interface{}
+	var serOk bool
+	if ser, serOk = input.(map[interface{}]interface{}); !serOk {
+		err = errors.New("invalid data for %s")
+		return
+	}
+
+	for serKey%s := range ser {
+		var index uint64
+		if index, err = goentangle.DeserializeUint64(serKey); err != nil {
+			err = errors.New("invalid field index for %s")
+			return
+		}
+
+		// Fields unknown to the receiver are ignored.
+		switch index {
+%s
+		}
+	}
+
+	return`, structDecl.Name, serValue, structDecl.Name, strings.Join(cases, "\n\n"))
+}
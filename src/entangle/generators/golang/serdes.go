@@ -3,23 +3,27 @@ package golang
 import (
 	"entangle/declarations"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 var (
 	deserializationSubTypeMapping = map[declarations.TypeClass]string{
-		declarations.BoolClass:    "Bool",
-		declarations.StringClass:  "String",
-		declarations.BinaryClass:  "Binary",
-		declarations.Float32Class: "Float32",
-		declarations.Float64Class: "Float64",
-		declarations.Int8Class:    "Int8",
-		declarations.Int16Class:   "Int16",
-		declarations.Int32Class:   "Int32",
-		declarations.Int64Class:   "Int64",
-		declarations.Uint8Class:   "Uint8",
-		declarations.Uint16Class:  "Uint16",
-		declarations.Uint32Class:  "Uint32",
-		declarations.Uint64Class:  "Uint64",
+		declarations.BoolClass:      "Bool",
+		declarations.StringClass:    "String",
+		declarations.BinaryClass:    "Binary",
+		declarations.Float32Class:   "Float32",
+		declarations.Float64Class:   "Float64",
+		declarations.Int8Class:      "Int8",
+		declarations.Int16Class:     "Int16",
+		declarations.Int32Class:     "Int32",
+		declarations.Int64Class:     "Int64",
+		declarations.Uint8Class:     "Uint8",
+		declarations.Uint16Class:    "Uint16",
+		declarations.Uint32Class:    "Uint32",
+		declarations.Uint64Class:    "Uint64",
+		declarations.TimestampClass: "Timestamp",
+		declarations.DurationClass:  "Duration",
 	}
 )
 
@@ -36,12 +40,22 @@ func nameOfDeserializerSubtype(typeDecl declarations.Type) string {
 
 	switch typeDecl.Class() {
 	case declarations.EnumClass:
-		return fmt.Sprintf("%s%s", nilable, typeDecl.(*declarations.EnumType).Enum().Name)
+		enumTypeDecl := typeDecl.(*declarations.EnumType)
+		return fmt.Sprintf("%s%s%s", nilable, importSubtypePrefix(enumTypeDecl.Import()), enumTypeDecl.Enum().Name)
 
 	case declarations.StructClass:
-		return fmt.Sprintf("%s%s", nilable, typeDecl.(*declarations.StructType).Struct().Name)
+		structTypeDecl := typeDecl.(*declarations.StructType)
+		return fmt.Sprintf("%s%s%s", nilable, importSubtypePrefix(structTypeDecl.Import()), structTypeDecl.Struct().Name)
 
-	case declarations.MapClass, declarations.ListClass:
+	case declarations.TypedefClass:
+		typedefTypeDecl := typeDecl.(*declarations.TypedefType)
+		return fmt.Sprintf("%s%s%s", nilable, importSubtypePrefix(typedefTypeDecl.Import()), typedefTypeDecl.Typedef().Name)
+
+	case declarations.UnionClass:
+		unionTypeDecl := typeDecl.(*declarations.UnionType)
+		return fmt.Sprintf("%s%s%s", nilable, importSubtypePrefix(unionTypeDecl.Import()), unionTypeDecl.Union().Name)
+
+	case declarations.MapClass, declarations.ListClass, declarations.SetClass:
 		return fmt.Sprintf("%s%s", nilable, suffixOfSerDes(typeDecl))
 
 	default:
@@ -49,6 +63,25 @@ func nameOfDeserializerSubtype(typeDecl declarations.Type) string {
 	}
 }
 
+// Prefix of subtype names for declarations from an imported definition.
+//
+// Turns the import name into upper camel case, i.e. shared_types becomes
+// SharedTypes.
+func importSubtypePrefix(importDecl *declarations.Import) string {
+	if importDecl == nil {
+		return ""
+	}
+
+	parts := strings.Split(importDecl.Name, "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+
+	return strings.Join(parts, "")
+}
+
 // Prefix of deserializer for type.
 //
 // Returns an empty value if the type does not need a custom deserializer.
@@ -67,6 +100,15 @@ func suffixOfSerDes(typeDecl declarations.Type) string {
 
 		return fmt.Sprintf("ListOf%s", nameOfDeserializerSubtype(elementTypeDecl))
 
+	case declarations.SetClass:
+		setTypeDecl := typeDecl.(*declarations.SetType)
+		elementTypeDecl := setTypeDecl.ElementType()
+
+		return fmt.Sprintf("SetOf%s", nameOfDeserializerSubtype(elementTypeDecl))
+
+	case declarations.TimestampClass, declarations.DurationClass:
+		return deserializationSubTypeMapping[typeDecl.Class()]
+
 	default:
 		return ""
 	}
@@ -112,21 +154,16 @@ func mapTypeToSerDesMap(typeDecl declarations.Type, m *map[string]declarations.T
 	switch typeDecl.Class() {
 	case declarations.MapClass:
 		mapTypeDecl := typeDecl.(*declarations.MapType)
-		valueTypeDecl := mapTypeDecl.ValueType()
-
-		switch valueTypeDecl.Class() {
-		case declarations.MapClass, declarations.ListClass:
-			mapTypeToSerDesMap(valueTypeDecl, m)
-		}
+		mapTypeToSerDesMap(mapTypeDecl.KeyType(), m)
+		mapTypeToSerDesMap(mapTypeDecl.ValueType(), m)
 
 	case declarations.ListClass:
 		listTypeDecl := typeDecl.(*declarations.ListType)
-		elementTypeDecl := listTypeDecl.ElementType()
+		mapTypeToSerDesMap(listTypeDecl.ElementType(), m)
 
-		switch elementTypeDecl.Class() {
-		case declarations.MapClass, declarations.ListClass:
-			mapTypeToSerDesMap(elementTypeDecl, m)
-		}
+	case declarations.SetClass:
+		setTypeDecl := typeDecl.(*declarations.SetType)
+		mapTypeToSerDesMap(setTypeDecl.ElementType(), m)
 	}
 
 	(*m)[suffix] = typeDecl
@@ -150,6 +187,11 @@ func buildSerDesMap(interfaceDecl *declarations.Interface) (m map[string]declara
 		}
 	}
 
+	// Iterate across all aliased types.
+	for _, typedefDecl := range interfaceDecl.Typedefs {
+		mapTypeToSerDesMap(typedefDecl.Type, &m)
+	}
+
 	// Iterate across all struct fields.
 	for _, structDecl := range interfaceDecl.Structs {
 		for _, field := range structDecl.Fields {
@@ -157,5 +199,58 @@ func buildSerDesMap(interfaceDecl *declarations.Interface) (m map[string]declara
 		}
 	}
 
+	// Iterate across all exception fields.
+	for _, excDecl := range interfaceDecl.Exceptions {
+		for _, field := range excDecl.Fields {
+			mapTypeToSerDesMap(field.Type, &m)
+		}
+	}
+
+	// Iterate across all union members.
+	for _, unionDecl := range interfaceDecl.Unions {
+		for _, field := range unionDecl.Fields {
+			mapTypeToSerDesMap(field.Type, &m)
+		}
+	}
+
 	return
 }
+
+// Build the list of set types declared for an interface.
+//
+// Sets are represented by named map types, which are declared for each set
+// type in the serialization/deserialization map. Sets are ordered by name.
+func buildSets(serDesMap map[string]declarations.Type) []*declarations.SetType {
+	names := make([]string, 0, len(serDesMap))
+	for name, typeDecl := range serDesMap {
+		if typeDecl.Class() == declarations.SetClass {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	sets := make([]*declarations.SetType, len(names))
+	for i, name := range names {
+		sets[i] = serDesMap[name].(*declarations.SetType)
+	}
+
+	return sets
+}
+
+// Build a serialization map for an interface.
+//
+// Timestamps and durations are serialized inline, leaving serializers to be
+// generated for the maps, lists and sets of a serialization/deserialization
+// map only.
+func buildSerializers(serDesMap map[string]declarations.Type) map[string]declarations.Type {
+	serializers := make(map[string]declarations.Type, len(serDesMap))
+
+	for suffix, typeDecl := range serDesMap {
+		switch typeDecl.Class() {
+		case declarations.MapClass, declarations.ListClass, declarations.SetClass:
+			serializers[suffix] = typeDecl
+		}
+	}
+
+	return serializers
+}
@@ -4,23 +4,27 @@ import (
 	"entangle/declarations"
 	"entangle/utils"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
 var (
 	simpleTypeClassMapping = map[declarations.TypeClass]string{
-		declarations.BoolClass:    "bool",
-		declarations.StringClass:  "string",
-		declarations.Float32Class: "float32",
-		declarations.Float64Class: "float64",
-		declarations.Int8Class:    "int8",
-		declarations.Int16Class:   "int16",
-		declarations.Int32Class:   "int32",
-		declarations.Int64Class:   "int64",
-		declarations.Uint8Class:   "uint8",
-		declarations.Uint16Class:  "uint16",
-		declarations.Uint32Class:  "uint32",
-		declarations.Uint64Class:  "uint64",
+		declarations.BoolClass:      "bool",
+		declarations.StringClass:    "string",
+		declarations.Float32Class:   "float32",
+		declarations.Float64Class:   "float64",
+		declarations.Int8Class:      "int8",
+		declarations.Int16Class:     "int16",
+		declarations.Int32Class:     "int32",
+		declarations.Int64Class:     "int64",
+		declarations.Uint8Class:     "uint8",
+		declarations.Uint16Class:    "uint16",
+		declarations.Uint32Class:    "uint32",
+		declarations.Uint64Class:    "uint64",
+		declarations.TimestampClass: "time.Time",
+		declarations.DurationClass:  "time.Duration",
 	}
 	simpleTypeClassDeserializationMapping = map[declarations.TypeClass]string{
 		declarations.BoolClass:    "DeserializeBool",
@@ -50,7 +54,7 @@ func documentationHelper(documentation []string, indentation int) string {
 
 	for _, paragraph := range documentation {
 		if len(lines) > 0 {
-			lines = append(lines, "")
+			lines = append(lines, prefix)
 		}
 
 		for _, line := range wrapper.Wrap(paragraph) {
@@ -67,6 +71,87 @@ func documentationHelper(documentation []string, indentation int) string {
 	return fmt.Sprintf("%s\n", strings.Join(lines, "\n"))
 }
 
+// Deprecation paragraph of a declaration.
+//
+// Follows the convention recognized by Go tools. Empty if the declaration is
+// not deprecated.
+func deprecationParagraph(annotations declarations.Annotations) string {
+	if !annotations.Deprecated() {
+		return ""
+	}
+
+	if reason := annotations.DeprecationReason(); reason != "" {
+		return fmt.Sprintf("Deprecated: %s", reason)
+	}
+
+	return "Deprecated: Do not use."
+}
+
+// Documentation of a declaration.
+//
+// Marks deprecated declarations by a deprecation paragraph following the
+// documentation paragraphs.
+func declarationDocumentationHelper(documentation []string, annotations declarations.Annotations, indentation int) string {
+	paragraph := deprecationParagraph(annotations)
+	if paragraph == "" {
+		return documentationHelper(documentation, indentation)
+	}
+
+	deprecated := make([]string, 0, len(documentation)+1)
+	deprecated = append(deprecated, documentation...)
+	deprecated = append(deprecated, paragraph)
+
+	return documentationHelper(deprecated, indentation)
+}
+
+// Deprecation comment of an undocumented declaration.
+func deprecationHelper(annotations declarations.Annotations, indentation int) string {
+	return declarationDocumentationHelper(nil, annotations, indentation)
+}
+
+// Documentation of a function.
+//
+// Documents each documented argument by a paragraph and lists the exceptions
+// declared to be raised by the function following the documentation
+// paragraphs.
+func functionDocumentationHelper(function *declarations.Function, indentation int) string {
+	documentation := make([]string, 0, len(function.Documentation)+len(function.Arguments)+1)
+	documentation = append(documentation, function.Documentation...)
+
+	for _, arg := range function.ArgumentsSortedByIndex() {
+		if len(arg.Documentation) > 0 {
+			documentation = append(documentation, fmt.Sprintf("%s: %s", arg.Name, strings.Join(arg.Documentation, " ")))
+		}
+	}
+
+	if len(function.Throws) > 0 {
+		names := make([]string, len(function.Throws))
+		for i, thrown := range function.Throws {
+			names[i] = qualifiedName(thrown.Import, thrown.Exception.Name)
+		}
+
+		paragraph := fmt.Sprintf("Raises %s.", names[len(names)-1])
+		if len(names) > 1 {
+			paragraph = fmt.Sprintf("Raises %s or %s.", strings.Join(names[:len(names)-1], ", "), names[len(names)-1])
+		}
+
+		documentation = append(documentation, paragraph)
+	}
+
+	return declarationDocumentationHelper(documentation, function.Annotations, indentation)
+}
+
+// Qualified name of a declaration.
+//
+// Declarations from imported definitions are qualified by the import name.
+func qualifiedName(importDecl *declarations.Import, name string) string {
+	if importDecl == nil {
+		return name
+	}
+
+	return fmt.Sprintf("%s.%s", importDecl.Name, name)
+}
+
 func typeHelper(typeDecl declarations.Type) string {
 	star := ""
 	if typeDecl.Nilable() {
@@ -82,10 +167,20 @@ func typeHelper(typeDecl declarations.Type) string {
 		return "[]byte"
 
 	case declarations.EnumClass:
-		return fmt.Sprintf("%s%s", star, typeDecl.(*declarations.EnumType).Enum().Name)
+		enumTypeDecl := typeDecl.(*declarations.EnumType)
+		return fmt.Sprintf("%s%s", star, qualifiedName(enumTypeDecl.Import(), enumTypeDecl.Enum().Name))
 
 	case declarations.StructClass:
-		return fmt.Sprintf("%s%s", star, typeDecl.(*declarations.StructType).Struct().Name)
+		structTypeDecl := typeDecl.(*declarations.StructType)
+		return fmt.Sprintf("%s%s", star, qualifiedName(structTypeDecl.Import(), polymorphicStructName(structTypeDecl.Struct())))
+
+	case declarations.TypedefClass:
+		typedefTypeDecl := typeDecl.(*declarations.TypedefType)
+		return fmt.Sprintf("%s%s", star, qualifiedName(typedefTypeDecl.Import(), typedefTypeDecl.Typedef().Name))
+
+	case declarations.UnionClass:
+		unionTypeDecl := typeDecl.(*declarations.UnionType)
+		return fmt.Sprintf("%s%s", star, qualifiedName(unionTypeDecl.Import(), unionTypeDecl.Union().Name))
 
 	case declarations.MapClass:
 		mapTypeDecl := typeDecl.(*declarations.MapType)
@@ -95,6 +190,9 @@ func typeHelper(typeDecl declarations.Type) string {
 		listTypeDecl := typeDecl.(*declarations.ListType)
 		return fmt.Sprintf("[]%s", typeHelper(listTypeDecl.ElementType()))
 
+	case declarations.SetClass:
+		return suffixOfSerDes(typeDecl)
+
 	default:
 		panic("Unimplemented type")
 	}
@@ -108,6 +206,111 @@ func nonNilableTypeHelper(typeDecl declarations.Type) string {
 	return name
 }
 
+// Go expression of a duration.
+//
+// Expressed in the largest unit of which the duration is a whole multiple,
+// e.g. 90 * time.Second for 1m30s.
+func durationHelper(d time.Duration) string {
+	for _, unit := range []struct {
+		Duration time.Duration
+		Name     string
+	}{
+		{time.Hour, "Hour"},
+		{time.Minute, "Minute"},
+		{time.Second, "Second"},
+		{time.Millisecond, "Millisecond"},
+		{time.Microsecond, "Microsecond"},
+	} {
+		if d%unit.Duration == 0 {
+			return fmt.Sprintf("%d * time.%s", d/unit.Duration, unit.Name)
+		}
+	}
+
+	return fmt.Sprintf("%d * time.Nanosecond", d)
+}
+
+// Go literal of a declared value of a type.
+//
+// Enumeration values are referred to by name.
+func valueHelper(typeDecl declarations.Type, value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+
+	case string:
+		return strconv.Quote(v)
+
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+
+	case int64:
+		return strconv.FormatInt(v, 10)
+
+	case uint64:
+		return strconv.FormatUint(v, 10)
+
+	case declarations.EnumValue:
+		enumTypeDecl := declarations.UnderlyingType(typeDecl).(*declarations.EnumType)
+		name := qualifiedName(enumTypeDecl.Import(), v.Name)
+
+		// Values of aliased enumerations must be converted to the alias.
+		if typedefTypeDecl, ok := typeDecl.(*declarations.TypedefType); ok {
+			return fmt.Sprintf("%s(%s)", qualifiedName(typedefTypeDecl.Import(), typedefTypeDecl.Typedef().Name), name)
+		}
+
+		return name
+
+	default:
+		panic("Unimplemented value")
+	}
+}
+
+// Constants of an interface by name.
+//
+// Selects either the constants of enumeration types or the other constants.
+// Enumeration values are variables, so constants of enumeration types, which
+// refer to the values by name, are declared as variables too.
+func constantsHelper(interfaceDecl *declarations.Interface, enumerations bool) []*declarations.Constant {
+	selected := make([]*declarations.Constant, 0, len(interfaceDecl.Constants))
+
+	for _, constDecl := range interfaceDecl.ConstantsSortedByName() {
+		if (declarations.UnderlyingType(constDecl.Type).Class() == declarations.EnumClass) == enumerations {
+			selected = append(selected, constDecl)
+		}
+	}
+
+	return selected
+}
+
+// Determine if a type is a union or an alias of a union.
+func unionTypeHelper(typeDecl declarations.Type) bool {
+	return declarations.UnderlyingType(typeDecl).Class() == declarations.UnionClass
+}
+
+// Determine if a type is a struct in a polymorphic hierarchy or an alias of
+// one.
+func polymorphicTypeHelper(typeDecl declarations.Type) bool {
+	structTypeDecl, ok := declarations.UnderlyingType(typeDecl).(*declarations.StructType)
+	return ok && structTypeDecl.Struct().Polymorphic()
+}
+
+// Determine if a type is represented by an interface.
+func interfaceTypeHelper(typeDecl declarations.Type) bool {
+	return unionTypeHelper(typeDecl) || polymorphicTypeHelper(typeDecl)
+}
+
+// Name of the type representing a struct.
+//
+// Structs in polymorphic hierarchies are represented by interfaces held by
+// the struct and any struct inheriting from it.
+func polymorphicStructName(structDecl *declarations.Struct) string {
+	if structDecl.Polymorphic() {
+		return fmt.Sprintf("Any%s", structDecl.Name)
+	}
+
+	return structDecl.Name
+}
+
 func canSkipBeforeFieldHelper(fieldDecl *declarations.Field, minimumDeserializedLength int) bool {
 	return fieldDecl.Index > uint(minimumDeserializedLength)
 }
@@ -129,12 +332,22 @@ func typeDeserializationMethodHelper(typeDecl declarations.Type) string {
 
 	switch typeDecl.Class() {
 	case declarations.EnumClass:
-		return fmt.Sprintf("Deserialize%s", typeDecl.(*declarations.EnumType).Enum().Name)
+		enumTypeDecl := typeDecl.(*declarations.EnumType)
+		return qualifiedName(enumTypeDecl.Import(), fmt.Sprintf("Deserialize%s", enumTypeDecl.Enum().Name))
 
 	case declarations.StructClass:
-		return fmt.Sprintf("Deserialize%s", typeDecl.(*declarations.StructType).Struct().Name)
+		structTypeDecl := typeDecl.(*declarations.StructType)
+		return qualifiedName(structTypeDecl.Import(), fmt.Sprintf("Deserialize%s", polymorphicStructName(structTypeDecl.Struct())))
+
+	case declarations.TypedefClass:
+		typedefTypeDecl := typeDecl.(*declarations.TypedefType)
+		return qualifiedName(typedefTypeDecl.Import(), fmt.Sprintf("Deserialize%s", typedefTypeDecl.Typedef().Name))
 
-	case declarations.MapClass, declarations.ListClass:
+	case declarations.UnionClass:
+		unionTypeDecl := typeDecl.(*declarations.UnionType)
+		return qualifiedName(unionTypeDecl.Import(), fmt.Sprintf("Deserialize%s", unionTypeDecl.Union().Name))
+
+	case declarations.MapClass, declarations.ListClass, declarations.SetClass, declarations.TimestampClass, declarations.DurationClass:
 		return nameOfDeserializer(typeDecl)
 
 	default:
@@ -160,6 +373,52 @@ func structFieldDeserializationCodeHelper(structDecl *declarations.Struct, field
 }
 
 func typeSerializationCodeHelper(typeDecl declarations.Type, source, target, errName string, indentation int) string {
+	// Unions and polymorphic structs are represented by interfaces, and
+	// nilable values are therefore pointers to interfaces, which have to be
+	// dereferenced. Polymorphic structs are serialized along with their
+	// discriminator.
+	if interfaceTypeHelper(typeDecl) {
+		method := "Serialize"
+		if polymorphicTypeHelper(typeDecl) {
+			method = "SerializePolymorphic"
+		}
+
+		if typeDecl.Nilable() {
+			return indent(fmt.Sprintf(`if %s != nil && *%s != nil {
+	if %s, %s = (*%s).%s(); %s != nil {
+		return
+	}
+}`, source, source, target, errName, source, method, errName), indentation)
+		}
+
+		return indent(fmt.Sprintf(`if %s == nil {
+	%s = errors.New("non-nilable type cannot be nil")
+	return
+}
+
+if %s, %s = %s.%s(); %s != nil {
+	return
+}`, source, errName, target, errName, source, method, errName), indentation)
+	}
+
+	// Timestamps are serialized as is, to be encoded as MessagePack timestamp
+	// extensions by the runtime, and durations as nanoseconds.
+	switch typeDecl.Class() {
+	case declarations.TimestampClass, declarations.DurationClass:
+		conversion := "%s"
+		if typeDecl.Class() == declarations.DurationClass {
+			conversion = "int64(%s)"
+		}
+
+		if typeDecl.Nilable() {
+			return indent(fmt.Sprintf(`if %s != nil {
+	%s = %s
+}`, source, target, fmt.Sprintf(conversion, "*"+source)), indentation)
+		}
+
+		return indent(fmt.Sprintf(`%s = %s`, target, fmt.Sprintf(conversion, source)), indentation)
+	}
+
 	if typeDecl.Nilable() {
 		switch typeDecl.Class() {
 		case declarations.BoolClass, declarations.StringClass, declarations.BinaryClass, declarations.Float32Class, declarations.Float64Class, declarations.Int8Class, declarations.Int16Class, declarations.Int32Class, declarations.Int64Class, declarations.Uint8Class, declarations.Uint16Class, declarations.Uint32Class, declarations.Uint64Class:
@@ -167,16 +426,16 @@ func typeSerializationCodeHelper(typeDecl declarations.Type, source, target, err
 	%s = *%s
 }`, source, target, source), indentation)
 
-		case declarations.StructClass, declarations.EnumClass:
+		case declarations.StructClass, declarations.EnumClass, declarations.TypedefClass:
 			return indent(fmt.Sprintf(`if %s != nil {
 	if %s, %s = %s.Serialize(); %s != nil {
 		return
 	}
 }`, source, target, errName, source, errName), indentation)
 
-		case declarations.ListClass, declarations.MapClass:
+		case declarations.ListClass, declarations.MapClass, declarations.SetClass:
 			return indent(fmt.Sprintf(`if %s != nil {
-	if %s, %s = %s(%s); err != %s {
+	if %s, %s = %s(%s); %s != nil {
 		return
 	}
 }`, source, target, errName, nameOfSerializer(typeDecl), source, errName), indentation)
@@ -189,12 +448,12 @@ func typeSerializationCodeHelper(typeDecl declarations.Type, source, target, err
 		case declarations.BoolClass, declarations.StringClass, declarations.BinaryClass, declarations.Float32Class, declarations.Float64Class, declarations.Int8Class, declarations.Int16Class, declarations.Int32Class, declarations.Int64Class, declarations.Uint8Class, declarations.Uint16Class, declarations.Uint32Class, declarations.Uint64Class:
 			return indent(fmt.Sprintf(`%s = %s`, target, source), indentation)
 
-		case declarations.StructClass, declarations.EnumClass:
+		case declarations.StructClass, declarations.EnumClass, declarations.TypedefClass:
 			return indent(fmt.Sprintf(`if %s, %s = %s.Serialize(); %s != nil {
 	return
 }`, target, errName, source, errName), indentation)
 
-		case declarations.ListClass, declarations.MapClass:
+		case declarations.ListClass, declarations.MapClass, declarations.SetClass:
 			return indent(fmt.Sprintf(`if %s == nil {
 	%s = errors.New("non-nilable type cannot be nil")
 	return
@@ -251,6 +510,36 @@ func deserializationCodeHelper(typeDecl declarations.Type) string {
 
 		return strings.Join(parts, "\n")
 
+	case declarations.SetClass:
+		setDecl := typeDecl.(*declarations.SetType)
+		elemType := setDecl.ElementType()
+
+		return fmt.Sprintf(`	var ser []interface{}
+	var serOk bool
+	if ser, serOk = input.([]interface{}); !serOk {
+		err = goentangle.ErrDeserializationError
+		return
+	}
+
+	des = make(%s, len(ser))
+
+	for _, serElem := range ser {
+		var desElem %s
+		if desElem, err = %s(serElem); err != nil {
+			return
+		}
+
+		// Sets cannot hold duplicate elements.
+		if _, found := des[desElem]; found {
+			err = goentangle.ErrDeserializationError
+			return
+		}
+
+		des[desElem] = struct{}{}
+	}
+
+	return`, typeHelper(typeDecl), typeHelper(elemType), typeDeserializationMethodHelper(elemType))
+
 	case declarations.MapClass:
 		mapDecl := typeDecl.(*declarations.MapType)
 		keyType := mapDecl.KeyType()
@@ -306,6 +595,23 @@ func deserializationCodeHelper(typeDecl declarations.Type) string {
 
 		return strings.Join(parts, "\n")
 
+	case declarations.TimestampClass:
+		return `	var desOk bool
+	if des, desOk = input.(time.Time); !desOk {
+		err = goentangle.ErrDeserializationError
+	}
+
+	return`
+
+	case declarations.DurationClass:
+		return `	var nanoseconds int64
+	if nanoseconds, err = goentangle.DeserializeInt64(input); err != nil {
+		return
+	}
+
+	des = time.Duration(nanoseconds)
+	return`
+
 	default:
 		panic("Cannot generate deserialization code for type")
 	}
@@ -326,6 +632,23 @@ func serializationCodeHelper(typeDecl declarations.Type) string {
 	ser = serArr
 	return`, typeSerializationCodeHelper(elemType, "des", "serArr[i]", "err", 2))
 
+	case declarations.SetClass:
+		setDecl := typeDecl.(*declarations.SetType)
+		elemType := setDecl.ElementType()
+
+		return fmt.Sprintf(`	serArr := make([]interface{}, 0, len(input))
+
+	for des := range input {
+		var serElem interface{}
+
+%s
+
+		serArr = append(serArr, serElem)
+	}
+
+	ser = serArr
+	return`, typeSerializationCodeHelper(elemType, "des", "serElem", "err", 2))
+
 	case declarations.MapClass:
 		mapDecl := typeDecl.(*declarations.MapType)
 		keyType := mapDecl.KeyType()
@@ -352,16 +675,28 @@ func serializationCodeHelper(typeDecl declarations.Type) string {
 	}
 }
 
-func structSerializationCodeHelper(structDecl *declarations.Struct) string {
-	parts := make([]string, 0, 2+len(structDecl.Fields))
+func structSerializationCodeHelper(name string, fieldList *declarations.FieldList, unknownElements bool) string {
+	parts := make([]string, 0, 3+len(fieldList.Fields))
 
-	parts = append(parts, fmt.Sprintf(`	serArr := make([]interface{}, %d)`, structDecl.SerializedLength()))
+	parts = append(parts, fmt.Sprintf(`	serArr := make([]interface{}, %d)`, fieldList.SerializedLength()))
+
+	for _, field := range fieldList.FieldsSortedByIndex() {
+		if check := constraintCheckCodeHelper(field.Type, field.Annotations, fmt.Sprintf("s.%s", field.Name), fmt.Sprintf("field %s in %s", field.Name, name), "err", false, 1); check != "" {
+			parts = append(parts, fmt.Sprintf(`	// Check %s.
+%s`, field.Name, check))
+		}
 
-	for _, field := range structDecl.FieldsSortedByIndex() {
 		parts = append(parts, fmt.Sprintf(`	// Serialize %s.
 %s`, field.Name, typeSerializationCodeHelper(field.Type, fmt.Sprintf("s.%s", field.Name), fmt.Sprintf("serArr[%d]", field.Index-1), "err", 1)))
 	}
 
+	if unknownElements {
+		parts = append(parts, `	// Re-emit unknown trailing elements.
+	if s.unknownElements != nil {
+		serArr = append(serArr, *s.unknownElements...)
+	}`)
+	}
+
 	parts = append(parts, `	ser = serArr
 	return`)
 
@@ -41,7 +41,9 @@ func (w *codeWriter) BlankLine() {
 
 // Write line.
 func (w *codeWriter) Line(line string) {
-	w.buffer.WriteString(strings.Repeat("    ", w.indent))
+	if len(line) > 0 {
+		w.buffer.WriteString(strings.Repeat("    ", w.indent))
+	}
 	w.buffer.WriteString(line)
 	w.buffer.Write([]byte { '\n' })
 }
@@ -53,12 +55,21 @@ func (w *codeWriter) Linef(format string, a ...interface{}) {
 
 // Write documentation.
 func (w *codeWriter) Documentation(docs []string) {
-	if docs == nil || len(docs) == 0 {
+	w.DocumentationWithFields(docs, nil)
+}
+
+// Write documentation followed by a field list.
+//
+// Fields are reStructuredText field list entries, e.g. `:param a: A.`, of
+// which continuation lines are indented.
+func (w *codeWriter) DocumentationWithFields(docs, fields []string) {
+	if len(docs) == 0 && len(fields) == 0 {
 		return
 	}
 
 	wrapper := utils.NewSimpleTextWrapper(79 - w.indent * 4 - 3)
-	lines := make([]string, 0, len(docs)*2)
+	fieldWrapper := utils.NewSimpleTextWrapper(79 - w.indent * 4 - 4)
+	lines := make([]string, 0, len(docs)*2 + len(fields))
 
 	for _, paragraph := range docs {
 		if len(lines) > 0 {
@@ -70,6 +81,24 @@ func (w *codeWriter) Documentation(docs []string) {
 		lines = append(lines, wrapper.Wrap(paragraph)...)
 	}
 
+	if len(lines) > 0 && len(fields) > 0 {
+		lines = append(lines, "")
+	}
+
+	for _, field := range fields {
+		if len(lines) == 0 {
+			field = fmt.Sprintf(`"""%s`, strings.TrimSpace(field))
+		}
+
+		for i, l := range fieldWrapper.Wrap(field) {
+			if i > 0 {
+				l = fmt.Sprintf("    %s", l)
+			}
+
+			lines = append(lines, l)
+		}
+	}
+
 	if len(lines) == 0 {
 		return
 	}
@@ -1,41 +1,75 @@
 package python2
 
 import (
+	"entangle/declarations"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // Generate clients.py.
 func generateClients(ctx *context) (src *SourceFile, err error) {
 	src = NewSourceFile("clients")
 
-	for _, srvc := range ctx.Interface.Services {
+	writeCallFunction(ctx, src)
+
+	for _, srvc := range ctx.Interface.ServicesSortedByInheritance() {
 		clientName := fmt.Sprintf("%sClient", srvc.Name)
 		src.Export(clientName)
 
 		w := newCodeWriter()
 
-		// Write the class definition.
-		src.ImportAs("entangle.client", "Client", "Client_")
-		w.Linef("class %s(Client_):", clientName)
+		// Write the class definition. Clients of inheriting services derive
+		// from the client of the parent service.
+		if srvc.Parent != nil {
+			w.Linef("class %s(%sClient):", clientName, srvc.ParentName)
+		} else {
+			src.ImportAs("entangle.client", "Client", "Client_")
+			w.Linef("class %s(Client_):", clientName)
+		}
 		w.Indent()
-		w.Documentation(srvc.Documentation)
+		w.Documentation(declarationDocumentation(srvc.Documentation, srvc.Annotations))
+
+		functions := srvc.DeclaredFunctionsSortedByName()
+		if len(functions) == 0 && !srvc.Annotations.Deprecated() {
+			w.Line("pass")
+		}
+
+		// Clients of deprecated services warn when initialized.
+		if srvc.Annotations.Deprecated() {
+			w.Line("def __init__(self_, *args, **kwargs):")
+			w.Indent()
+			writeDeprecationWarning(srvc.Name, srvc.Annotations, w, src)
+			w.Linef("super(%s, self_).__init__(*args, **kwargs)", clientName)
+			w.Unindent()
+			w.BlankLine()
+		}
 
 		// Write each function.
-		for _, fun := range srvc.Functions {
+		for _, fun := range functions {
 			// Write the function definition.
-			args := make([]string, len(fun.Arguments) + 3)
-			args[0] = "self_"
-			args[len(args) - 2] = "trace=False"
-			args[len(args) - 1] = "notify=False"
+			//
+			// One-way functions are always notified, and thus never traced.
+			args := make([]string, 0, len(fun.Arguments) + 2)
+			args = append(args, "self_")
+
+			for _, arg := range fun.ArgumentsSortedByIndex() {
+				args = append(args, snakeCaseString(arg.Name))
+			}
 
-			for i, arg := range fun.ArgumentsSortedByIndex() {
-				args[i + 1] = snakeCaseString(arg.Name)
+			if !fun.Oneway {
+				args = append(args, "trace=False")
 			}
 
 			w.ParentherizedWithArguments(fmt.Sprintf("def %s", snakeCaseString(fun.Name)), ":", args...)
 			w.Indent()
 
-			w.Documentation(fun.Documentation)
+			w.DocumentationWithFields(functionDocumentation(fun), functionArgumentFields(fun))
+
+			if fun.Annotations.Deprecated() {
+				writeDeprecationWarning(fmt.Sprintf("%s.%s", srvc.Name, fun.Name), fun.Annotations, w, src)
+				w.BlankLine()
+			}
 
 			// Write argument serialization.
 			src.ImportAs("io", "BytesIO", "BytesIO_")
@@ -51,9 +85,10 @@ func generateClients(ctx *context) (src *SourceFile, err error) {
 					Source: name,
 					Description: fmt.Sprintf("argument %s", name),
 					Type: arg.Type,
+					Annotations: arg.Annotations,
 				}
 			}
-			writeInlinePacking(packDecls, w, src)
+			writeInlinePacking(packDecls, "", w, src)
 
 			if len(packDecls) > 0 {
 				w.BlankLine()
@@ -63,7 +98,24 @@ func generateClients(ctx *context) (src *SourceFile, err error) {
 
 			// Write the service calling.
 			w.Comment("Call the service.")
-			w.ParentherizedWithArguments("response = self_._call", "", fmt.Sprintf("'%s'", fun.Name), "stream_.getvalue()", "trace=trace", "notify=notify")
+			notify, trace := "False", "trace"
+			if fun.Oneway {
+				notify, trace = "True", "False"
+			}
+
+			if fun.Timeout() > 0 || fun.Idempotent() {
+				seconds, attempts := "None", "1"
+				if fun.Timeout() > 0 {
+					seconds = strconv.FormatFloat(fun.Timeout().Seconds(), 'g', -1, 64)
+				}
+				if fun.Idempotent() {
+					attempts = "IDEMPOTENT_CALL_ATTEMPTS_"
+				}
+
+				w.ParentherizedWithArguments("response = call_function_", "", "self_", fmt.Sprintf("'%s'", fun.Name), "stream_.getvalue()", trace, notify, seconds, attempts)
+			} else {
+				w.ParentherizedWithArguments("response = self_._call", "", fmt.Sprintf("'%s'", fun.Name), "stream_.getvalue()", fmt.Sprintf("trace=%s", trace), fmt.Sprintf("notify=%s", notify))
+			}
 			w.BlankLine()
 
 			// Write the exception response handling.
@@ -72,16 +124,14 @@ func generateClients(ctx *context) (src *SourceFile, err error) {
 			src.ImportAs(".exceptions", "parse_exception", "parse_exception_")
 			w.Line("if isinstance(response, ExceptionMessage_):")
 			w.Indent()
-			w.ParentherizedWithArguments("raise parse_exception_", "", "response.definition", "response.name", "response.description")
+			w.ParentherizedWithArguments("raise parse_exception_", "", "response.definition", "response.name", "response.description", "getattr(response, 'fields', None)")
 			w.Unindent()
 			w.BlankLine()
 
-			w.Line("if notify:")
-			w.Line("    return (None, None)")
-			w.BlankLine()
-
 			// Write the result response handling.
-			if fun.ReturnType != nil {
+			if fun.Oneway {
+				w.Line("return (None, None)")
+			} else if fun.ReturnType != nil {
 				w.Comment("Deserialize result.")
 				if fun.ReturnType.Nilable() {
 					w.Line("result = None")
@@ -101,3 +151,122 @@ func generateClients(ctx *context) (src *SourceFile, err error) {
 
 	return
 }
+
+// Write the function calling functions with timeouts or retries.
+//
+// Only written if a function of the interface declares a timeout or is
+// idempotent. Calls are given up on by leaving them to finish in a background
+// thread, and retried on the connection of the client, as the runtime can
+// neither bound the duration of a call nor redial a connection.
+func writeCallFunction(ctx *context, src *SourceFile) {
+	needed := false
+	for _, srvc := range ctx.Interface.Services {
+		for _, fun := range srvc.Functions {
+			needed = needed || fun.Timeout() > 0 || fun.Idempotent()
+		}
+	}
+
+	if !needed {
+		return
+	}
+
+	src.ImportAs("sys", "exc_info", "exc_info_")
+	src.ImportAs("threading", "Thread", "Thread_")
+	src.ImportAs(".exceptions", "TimeoutError", "TimeoutError_")
+
+	w := newCodeWriter()
+	w.Comment("Attempts of calls to idempotent functions failing due to connection")
+	w.Comment("failures.")
+	w.Line("IDEMPOTENT_CALL_ATTEMPTS_ = 3")
+	w.BlankLine()
+	w.BlankLine()
+	w.Line("def call_function_(client, name, data, trace, notify, seconds, attempts):")
+	w.Indent()
+	w.Line(`"""Call a function.`)
+	w.BlankLine()
+	w.Line(`:param seconds:`)
+	w.Line(`    seconds after which the call is given up on and left to finish in the`)
+	w.Line("    background, or ``None`` to wait for the result indefinitely.")
+	w.Line(`:param attempts: attempts of the call failing due to connection failures.`)
+	w.Line(`:raises TimeoutError: if the call is given up on.`)
+	w.Line(`"""`)
+	w.BlankLine()
+	w.Line("for attempt in range(1, attempts + 1):")
+	w.Indent()
+	w.Line("result = []")
+	w.BlankLine()
+	w.Line("def call():")
+	w.Indent()
+	w.Line("try:")
+	w.Line("    response = client._call(name, data, trace=trace, notify=notify)")
+	w.Line("    result.append((response, None))")
+	w.Line("except Exception:")
+	w.Line("    result.append((None, exc_info_()))")
+	w.Unindent()
+	w.BlankLine()
+	w.Line("if seconds is None:")
+	w.Line("    call()")
+	w.Line("else:")
+	w.Line("    thread = Thread_(target=call)")
+	w.Line("    thread.daemon = True")
+	w.Line("    thread.start()")
+	w.Line("    thread.join(seconds)")
+	w.BlankLine()
+	w.Line("if not result:")
+	w.Line("    raise TimeoutError_('%s exceeded its timeout of %gs' % (name, seconds))")
+	w.BlankLine()
+	w.Line("response, exc = result[0]")
+	w.Line("if exc is None:")
+	w.Line("    return response")
+	w.Line("if attempt == attempts or not issubclass(exc[0], EnvironmentError):")
+	w.Line("    raise exc[0], exc[1], exc[2]")
+	w.Unindent()
+	w.Unindent()
+
+	src.AddBlock(w.Bytes())
+}
+
+// Documentation of a function.
+//
+// Lists the exceptions declared to be raised by the function following the
+// documentation paragraphs.
+func functionDocumentation(fun *declarations.Function) []string {
+	if len(fun.Throws) == 0 {
+		return declarationDocumentation(fun.Documentation, fun.Annotations)
+	}
+
+	names := make([]string, len(fun.Throws))
+	for i, thrown := range fun.Throws {
+		if thrown.Import != nil {
+			names[i] = fmt.Sprintf(":class:`%s.exceptions.%s`", thrown.Import.Interface.Name, thrown.Exception.Name)
+		} else {
+			names[i] = fmt.Sprintf(":class:`%s`", thrown.Exception.Name)
+		}
+	}
+
+	paragraph := fmt.Sprintf("Raises %s.", names[len(names) - 1])
+	if len(names) > 1 {
+		paragraph = fmt.Sprintf("Raises %s or %s.", strings.Join(names[:len(names) - 1], ", "), names[len(names) - 1])
+	}
+
+	documentation := make([]string, 0, len(fun.Documentation) + 1)
+	documentation = append(documentation, fun.Documentation...)
+	documentation = append(documentation, paragraph)
+
+	return declarationDocumentation(documentation, fun.Annotations)
+}
+
+// Field list entries documenting the arguments of a function.
+//
+// Undocumented arguments are left out.
+func functionArgumentFields(fun *declarations.Function) []string {
+	fields := make([]string, 0, len(fun.Arguments))
+
+	for _, arg := range fun.ArgumentsSortedByIndex() {
+		if len(arg.Documentation) > 0 {
+			fields = append(fields, fmt.Sprintf(":param %s: %s", snakeCaseString(arg.Name), strings.Join(arg.Documentation, " ")))
+		}
+	}
+
+	return fields
+}
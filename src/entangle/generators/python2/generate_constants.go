@@ -0,0 +1,64 @@
+package python2
+
+import (
+	"entangle/declarations"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Python name of a constant.
+func constantName(constDecl *declarations.Constant) string {
+	return strings.ToUpper(snakeCaseString(constDecl.Name))
+}
+
+// Python literal of a declared value.
+func valueLiteral(typeDecl declarations.Type, value interface{}, w *codeWriter, src *SourceFile) string {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return "True"
+		}
+		return "False"
+
+	case string:
+		return fmt.Sprintf("u%s", strconv.QuoteToASCII(v))
+
+	case float64:
+		literal := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(literal, ".e") {
+			literal += ".0"
+		}
+		return literal
+
+	case int64:
+		return strconv.FormatInt(v, 10)
+
+	case uint64:
+		return strconv.FormatUint(v, 10)
+
+	case declarations.EnumValue:
+		return fmt.Sprintf("%s.%s", referenceTypeClass(declarations.UnderlyingType(typeDecl), w, src), v.Name)
+
+	default:
+		panic("Unimplemented value")
+	}
+}
+
+// Generate constants.py.
+func generateConstants(ctx *context) (src *SourceFile, err error) {
+	src = NewSourceFile("constants")
+	w := newCodeWriter()
+
+	for _, constDecl := range ctx.Interface.ConstantsSortedByName() {
+		name := constantName(constDecl)
+		src.Export(name)
+
+		w.Linef("%s = %s", name, valueLiteral(constDecl.Type, constDecl.Value, w, src))
+		w.Documentation(constDecl.Documentation)
+	}
+
+	src.AddBlock(w.Bytes())
+
+	return
+}
@@ -0,0 +1,61 @@
+package python2
+
+import (
+	"entangle/declarations"
+	"fmt"
+	"strconv"
+)
+
+// Write checks of the constraints declared for a value.
+//
+// Violations are reported by raising the named exception, which is expected to
+// have been imported.
+func writeConstraintChecks(source, description, exception string, typeDecl declarations.Type, annotations declarations.Annotations, w *codeWriter, src *SourceFile) {
+	constraints := annotations.Constraints()
+	if !constraints.Any() {
+		return
+	}
+
+	if typeDecl.Nilable() {
+		w.Linef("if %s is not None:", source)
+		w.Indent()
+	}
+
+	check := func(condition, message string) {
+		w.Linef("if %s:", condition)
+		w.Indent()
+		w.RaiseException(exception, message)
+		w.Unindent()
+	}
+
+	if constraints.Min != nil {
+		bound := valueLiteral(typeDecl, constraints.Min, w, src)
+		check(fmt.Sprintf("%s < %s", source, bound), fmt.Sprintf("value of %s must be at least %s", description, bound))
+	}
+
+	if constraints.Max != nil {
+		bound := valueLiteral(typeDecl, constraints.Max, w, src)
+		check(fmt.Sprintf("%s > %s", source, bound), fmt.Sprintf("value of %s must be at most %s", description, bound))
+	}
+
+	if constraints.MinLength != nil {
+		check(fmt.Sprintf("len(%s) < %d", source, constraints.MinLength), fmt.Sprintf("length of %s must be at least %d", description, constraints.MinLength))
+	}
+
+	if constraints.MaxLength != nil {
+		check(fmt.Sprintf("len(%s) > %d", source, constraints.MaxLength), fmt.Sprintf("length of %s must be at most %d", description, constraints.MaxLength))
+	}
+
+	if constraints.Pattern != "" {
+		src.ImportAs("re", "search", "search_")
+		check(fmt.Sprintf("search_(u%s, %s) is None", strconv.QuoteToASCII(constraints.Pattern), source), fmt.Sprintf("%s does not match the required pattern", description))
+	}
+
+	if constraints.NonEmpty {
+		check(fmt.Sprintf("not %s", source), fmt.Sprintf("%s must not be empty", description))
+	}
+
+	if typeDecl.Nilable() {
+		w.Unindent()
+	}
+}
@@ -0,0 +1,147 @@
+package python2
+
+import (
+	"entangle/declarations"
+	"fmt"
+	"strconv"
+)
+
+// Documentation of a declaration.
+//
+// Marks deprecated declarations by a deprecation paragraph following the
+// documentation paragraphs.
+func declarationDocumentation(documentation []string, annotations declarations.Annotations) []string {
+	if !annotations.Deprecated() {
+		return documentation
+	}
+
+	paragraph := "Deprecated."
+	if reason := annotations.DeprecationReason(); reason != "" {
+		paragraph = fmt.Sprintf("Deprecated: %s", reason)
+	}
+
+	deprecated := make([]string, 0, len(documentation)+1)
+	deprecated = append(deprecated, documentation...)
+	deprecated = append(deprecated, paragraph)
+
+	return deprecated
+}
+
+// Write a deprecation warning.
+//
+// The written code warns that the named declaration is deprecated, attributing
+// the warning to the caller.
+func writeDeprecationWarning(name string, annotations declarations.Annotations, w *codeWriter, src *SourceFile) {
+	message := fmt.Sprintf("%s is deprecated", name)
+	if reason := annotations.DeprecationReason(); reason != "" {
+		message = fmt.Sprintf("%s: %s", message, reason)
+	}
+
+	src.ImportAs("warnings", "warn", "warn_")
+	w.ParentherizedWithArguments("warn_", "", strconv.QuoteToASCII(message), "DeprecationWarning", "stacklevel=2")
+}
+
+// Write properties for deprecated fields.
+//
+// The values of deprecated fields are stored in slots prefixed by an
+// underscore, and accessed through properties warning about the deprecation.
+func writeDeprecatedFieldProperties(clsName string, fields []*declarations.Field, w *codeWriter, src *SourceFile) {
+	for _, field := range fields {
+		if !field.Annotations.Deprecated() {
+			continue
+		}
+
+		name := snakeCaseString(field.Name)
+		desc := fmt.Sprintf("%s.%s", clsName, name)
+
+		w.Line("@property")
+		w.Linef("def %s(self):", name)
+		w.Indent()
+		w.Documentation(declarationDocumentation(field.Documentation, field.Annotations))
+		writeDeprecationWarning(desc, field.Annotations, w, src)
+		w.Linef("return self._%s", name)
+		w.Unindent()
+		w.BlankLine()
+
+		w.Linef("@%s.setter", name)
+		w.Linef("def %s(self, value):", name)
+		w.Indent()
+		writeDeprecationWarning(desc, field.Annotations, w, src)
+		w.Linef("self._%s = value", name)
+		w.Unindent()
+		w.BlankLine()
+	}
+}
+
+// Write the initialization of a field from the initializer argument.
+//
+// Passing a value for a deprecated field warns about the deprecation.
+func writeFieldInitialization(clsName string, field *declarations.Field, w *codeWriter, src *SourceFile) {
+	name := snakeCaseString(field.Name)
+	w.Linef("self.%s = %s", fieldAttributeName(field), name)
+
+	if field.Annotations.Deprecated() {
+		w.Linef("if %s is not None:", name)
+		w.Indent()
+		writeDeprecationWarning(fmt.Sprintf("%s.%s", clsName, name), field.Annotations, w, src)
+		w.Unindent()
+	}
+}
+
+// Name of the attribute storing the value of a field.
+func fieldAttributeName(field *declarations.Field) string {
+	if field.Annotations.Deprecated() {
+		return fmt.Sprintf("_%s", snakeCaseString(field.Name))
+	}
+
+	return snakeCaseString(field.Name)
+}
+
+// Write the metaclass of an enumeration with deprecated values.
+//
+// The metaclass warns about the deprecation when deprecated values are
+// accessed through the class. Returns the name of the metaclass, or an empty
+// name if none of the values are deprecated.
+func writeEnumMetaclass(enum *declarations.Enum, src *SourceFile) string {
+	var deprecated []declarations.EnumValue
+
+	for _, value := range enum.ValuesSortedByValue() {
+		if value.Annotations.Deprecated() {
+			deprecated = append(deprecated, value)
+		}
+	}
+
+	if len(deprecated) == 0 {
+		return ""
+	}
+
+	name := fmt.Sprintf("_%sMeta", enum.Name)
+
+	w := newCodeWriter()
+	w.Linef("class %s(type(%s_)):", name, enumBaseClass(enum))
+	w.Indent()
+	w.Documentation([]string{fmt.Sprintf("Metaclass of :class:`%s`.", enum.Name)})
+
+	w.Line("def __getattribute__(cls, name):")
+	w.Indent()
+
+	for i, value := range deprecated {
+		if i == 0 {
+			w.Linef("if name == '%s':", value.Name)
+		} else {
+			w.Linef("elif name == '%s':", value.Name)
+		}
+
+		w.Indent()
+		writeDeprecationWarning(fmt.Sprintf("%s.%s", enum.Name, value.Name), value.Annotations, w, src)
+		w.Unindent()
+	}
+
+	w.Linef("return super(%s, cls).__getattribute__(name)", name)
+	w.Unindent()
+	w.Unindent()
+
+	src.AddBlock(w.Bytes())
+
+	return name
+}
@@ -46,6 +46,33 @@ func generateDeserialization(ctx *context) (src *SourceFile, err error) {
 			w.BlankLine()
 			w.Line("return result")
 
+		case declarations.SetClass:
+			setDecl := typeDecl.(*declarations.SetType)
+			elemType := setDecl.ElementType()
+
+			w.Line("if value is None or not isinstance(value, (list, tuple)):")
+			w.Indent()
+			w.RaiseException("DeserializationError_", fmt.Sprintf("cannot deserialize input as a set"))
+			w.Unindent()
+			w.BlankLine()
+
+			w.Line("result = set()")
+			w.BlankLine()
+
+			w.Line("for ser in value:")
+			w.Indent()
+			w.Line("des = None")
+			writeSingleInlineDeserialization("ser", "des", "set element", "", elemType, w, src)
+			w.Line("if des in result:")
+			w.Indent()
+			w.RaiseException("DeserializationError_", "duplicate set element")
+			w.Unindent()
+			w.Line("result.add(des)")
+			w.Unindent()
+
+			w.BlankLine()
+			w.Line("return result")
+
 		case declarations.MapClass:
 			mapDecl := typeDecl.(*declarations.MapType)
 			keyType := mapDecl.KeyType()
@@ -73,6 +100,61 @@ func generateDeserialization(ctx *context) (src *SourceFile, err error) {
 			w.BlankLine()
 			w.Line("return result")
 
+		// Timestamps are deserialized from MessagePack timestamp extensions
+		// in any of their formats, unless already deserialized by the
+		// runtime.
+		case declarations.TimestampClass:
+			src.ImportAs("datetime", "datetime", "datetime_")
+			src.ImportAs("datetime", "timedelta", "timedelta_")
+			src.ImportAs("struct", "unpack", "struct_unpack_")
+
+			w.Line("if isinstance(value, datetime_):")
+			w.Indent()
+			w.Line("return value")
+			w.Unindent()
+			w.BlankLine()
+
+			w.Line("if getattr(value, 'code', None) != -1 or not isinstance(getattr(value, 'data', None), bytes):")
+			w.Indent()
+			w.RaiseException("DeserializationError_", "cannot deserialize input as a timestamp")
+			w.Unindent()
+			w.BlankLine()
+
+			w.Line("if len(value.data) == 4:")
+			w.Indent()
+			w.Line("seconds, = struct_unpack_('>I', value.data)")
+			w.Line("nanoseconds = 0")
+			w.Unindent()
+			w.Line("elif len(value.data) == 8:")
+			w.Indent()
+			w.Line("packed, = struct_unpack_('>Q', value.data)")
+			w.Line("seconds, nanoseconds = packed & 0x3ffffffff, packed >> 34")
+			w.Unindent()
+			w.Line("elif len(value.data) == 12:")
+			w.Indent()
+			w.Line("nanoseconds, seconds = struct_unpack_('>Iq', value.data)")
+			w.Unindent()
+			w.Line("else:")
+			w.Indent()
+			w.RaiseException("DeserializationError_", "invalid timestamp extension size")
+			w.Unindent()
+			w.BlankLine()
+
+			w.Line("if nanoseconds > 999999999:")
+			w.Indent()
+			w.RaiseException("DeserializationError_", "invalid timestamp nanoseconds")
+			w.Unindent()
+			w.BlankLine()
+
+			w.Line("return datetime_(1970, 1, 1) + timedelta_(seconds=seconds, microseconds=nanoseconds // 1000)")
+
+		// Durations are deserialized from nanoseconds.
+		case declarations.DurationClass:
+			src.ImportAs("datetime", "timedelta", "timedelta_")
+			src.ImportAs("entangle.deserialization", "deserialize_int64", "deserialize_int64_")
+
+			w.Line("return timedelta_(microseconds=deserialize_int64_(value) // 1000)")
+
 		default:
 			panic("Cannot generate deserialization code for type")
 		}
@@ -1,6 +1,7 @@
 package python2
 
 import (
+	"entangle/declarations"
 	"fmt"
 )
 
@@ -9,7 +10,7 @@ func generateExceptions(ctx *context) (src *SourceFile, err error) {
 	src = NewSourceFile("exceptions")
 
 	// Write the individual exceptions.
-	for _, exc := range ctx.Interface.Exceptions {
+	for _, exc := range ctx.Interface.ExceptionsSortedByName() {
 		src.Export(exc.Name)
 
 		w := newCodeWriter()
@@ -18,18 +19,35 @@ func generateExceptions(ctx *context) (src *SourceFile, err error) {
 		src.ImportAs("entangle.exceptions", "EntangleException", "EntangleException_")
 		w.Linef("class %s(EntangleException_):", exc.Name)
 		w.Indent()
-		w.Documentation(exc.Documentation)
+		w.Documentation(declarationDocumentation(exc.Documentation, exc.Annotations))
 
 		w.Linef("definition = '%s'", ctx.Interface.Name)
 		w.Linef("name = '%s'", exc.Name)
 
+		if len(exc.Fields) > 0 {
+			writeExceptionFields(exc, w, src)
+		}
+
 		w.Unindent()
 
 		src.AddBlock(w.Bytes())
 	}
 
-	// Write the exception mapping followed by the exception parser.
+	// Write the timeout exception, raised by clients giving up on calls and by
+	// servers giving up on implementations.
 	w := newCodeWriter()
+	src.Export("TimeoutError")
+	src.ImportAs("entangle.exceptions", "EntangleException", "EntangleException_")
+	w.Line("class TimeoutError(EntangleException_):")
+	w.Indent()
+	w.Documentation([]string{"Raised when a call exceeds its timeout."})
+	w.Line("definition = 'entangle'")
+	w.Line("name = 'Timeout'")
+	w.Unindent()
+	src.AddBlock(w.Bytes())
+
+	// Write the exception mapping followed by the exception parser.
+	w = newCodeWriter()
 	src.ImportAs("entangle.exceptions", "parse_exception", "entangle_parse_exception")
 
 	mapping := make(map[string]string, len(ctx.Interface.Exceptions))
@@ -43,13 +61,14 @@ func generateExceptions(ctx *context) (src *SourceFile, err error) {
 	w.BlankLine()
 	w.BlankLine()
 
-	w.Line(`def parse_exception(definition, name, message):`)
+	w.Line(`def parse_exception(definition, name, message, fields=None):`)
 	w.Indent()
 	w.Line(`"""Parse an exception.`)
 	w.BlankLine()
 	w.Line(`:param definition: Definition.`)
 	w.Line(`:param name: Name.`)
 	w.Line(`:param message: Message.`)
+	w.Line(`:param fields: Serialized exception fields if any.`)
 	w.Line(`:returns:`)
 	w.Line("    the parsed exception or :class:`entangle.exceptions.UnknownException`")
 	w.Line(`    if the exception is not known.`)
@@ -57,9 +76,35 @@ func generateExceptions(ctx *context) (src *SourceFile, err error) {
 	w.BlankLine()
 	w.Linef(`if definition == '%s':`, ctx.Interface.Name)
 	w.Line(`    try:`)
-	w.Line(`        return exceptions[name](message)`)
+	w.Line(`        exc = exceptions[name](message)`)
 	w.Line(`    except KeyError:`)
 	w.Line(`        pass`)
+	w.Line(`    else:`)
+	w.Line(`        if fields is not None and hasattr(exc, 'deserialize_fields'):`)
+	w.Line(`            exc.deserialize_fields(fields)`)
+	w.Line(`        return exc`)
+	w.BlankLine()
+
+	// Exceptions of imported definitions are parsed by the modules generated
+	// from the imported definitions.
+	parsedDefinitions := map[string]bool{ctx.Interface.Name: true}
+
+	for _, importDecl := range ctx.Interface.ImportsSortedByName() {
+		definition := importDecl.Interface.Name
+		if len(importDecl.Interface.Exceptions) == 0 || parsedDefinitions[definition] {
+			continue
+		}
+		parsedDefinitions[definition] = true
+
+		alias := fmt.Sprintf("%s_parse_exception", importDecl.Name)
+		src.ImportAs(fmt.Sprintf("%s.exceptions", definition), "parse_exception", alias)
+		w.Linef(`if definition == '%s':`, definition)
+		w.Linef(`    return %s(definition, name, message, fields)`, alias)
+		w.BlankLine()
+	}
+
+	w.Line(`if definition == 'entangle' and name == 'Timeout':`)
+	w.Line(`    return TimeoutError(message)`)
 	w.BlankLine()
 	w.Line(`return entangle_parse_exception(definition, name, message)`)
 	w.Unindent()
@@ -69,3 +114,64 @@ func generateExceptions(ctx *context) (src *SourceFile, err error) {
 
 	return
 }
+
+// Write the fields of an exception.
+//
+// Fields are initialized from keyword arguments and deserialized from the
+// extra exception payload element.
+func writeExceptionFields(exc *declarations.Exception, w *codeWriter, src *SourceFile) {
+	fields := exc.FieldsSortedByIndex()
+	fieldNames := make([]string, len(fields))
+
+	for i, field := range fields {
+		fieldNames[i] = snakeCaseString(field.Name)
+	}
+
+	// Write the initializer.
+	w.BlankLine()
+
+	args := make([]string, len(fieldNames)+2)
+	args[0] = "self"
+	args[1] = "message"
+	for i, n := range fieldNames {
+		args[i+2] = fmt.Sprintf("%s=None", n)
+	}
+
+	w.ParentherizedWithArguments("def __init__", ":", args...)
+	w.Indent()
+	w.Linef("super(%s, self).__init__(message)", exc.Name)
+
+	for _, field := range fields {
+		writeFieldInitialization(exc.Name, field, w, src)
+	}
+
+	w.Unindent()
+	w.BlankLine()
+
+	// Write the properties of deprecated fields.
+	writeDeprecatedFieldProperties(exc.Name, fields, w, src)
+
+	// Write the fields deserializer.
+	w.Line("def deserialize_fields(self, ser):")
+	w.Indent()
+	w.Line(`"""Deserialize fields.`)
+	w.BlankLine()
+	w.Line(`:raises entangle.DeserializationError:`)
+	w.Line(`    if the serialized input could not be deserialized.`)
+	w.Line(`"""`)
+	w.BlankLine()
+
+	desDecls := make([]inlineDeserializationDecl, exc.SerializedLength())
+	for i, field := range fields {
+		desDecls[field.Index-1] = inlineDeserializationDecl{
+			Target:      fmt.Sprintf("self.%s", fieldAttributeName(field)),
+			Description: fmt.Sprintf("property %s", fieldNames[i]),
+			Type:        field.Type,
+			Default:     field.Default,
+			Annotations: field.Annotations,
+		}
+	}
+	writeInlineDeserialization(desDecls, exc.Name, "", w, src)
+
+	w.Unindent()
+}
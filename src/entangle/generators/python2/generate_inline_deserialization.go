@@ -33,6 +33,15 @@ type inlineDeserializationDecl struct {
 	//
 	// If nil, a nil value is written instead of serialization.
 	Type declarations.Type
+
+	// Default value.
+	//
+	// If not nil, the default value is assigned to the target if the input
+	// is too short to contain a value.
+	Default interface{}
+
+	// Annotations declaring constraints on the value.
+	Annotations declarations.Annotations
 }
 
 // Write inline deserialization of a single variable.
@@ -40,6 +49,8 @@ type inlineDeserializationDecl struct {
 // If a predicate is to be evaluated prior to accessing the source, the
 // predicate must be provided.
 func writeSingleInlineDeserialization(source, target, description, predicate string, typeDecl declarations.Type, w *codeWriter, src *SourceFile) {
+	typeDecl = declarations.UnderlyingType(typeDecl)
+
 	src.ImportAs("entangle.exceptions", "DeserializationError", "DeserializationError_")
 
 	if !typeDecl.Nilable() {
@@ -68,23 +79,27 @@ func writeSingleInlineDeserialization(source, target, description, predicate str
 		src.ImportAs("entangle.deserialization", deserializer, fmt.Sprintf("%s_", deserializer))
 		w.Linef("%s = %s_(%s)", target, deserializer, source)
 
-	case declarations.EnumClass, declarations.StructClass:
-		var clsName string
-		if typeDecl.Class() == declarations.StructClass {
-			clsName = typeDecl.(*declarations.StructType).Struct().Name
-		} else {
-			clsName = typeDecl.(*declarations.EnumType).Enum().Name
-		}
+	case declarations.TimestampClass, declarations.DurationClass:
+		deserializer := nameOfDeserializer(typeDecl)
 
 		if src.moduleName == "deserialization" {
-			w.Linef("from .types import %s", clsName)
+			w.Linef("%s = %s(%s)", target, deserializer, source)
 		} else {
-			src.Import(".types", clsName)
+			src.ImportAs(".deserialization", deserializer, fmt.Sprintf("%s_", deserializer))
+			w.Linef("%s = %s_(%s)", target, deserializer, source)
 		}
 
-		w.Linef("%s = %s.deserialize(%s)", target, clsName, source)
+	case declarations.EnumClass, declarations.StructClass, declarations.UnionClass:
+		clsName := referenceTypeClass(typeDecl, w, src)
 
-	case declarations.MapClass, declarations.ListClass:
+		// Polymorphic structs are deserialized by their discriminator.
+		if structTypeDecl, ok := typeDecl.(*declarations.StructType); ok && structTypeDecl.Struct().Polymorphic() {
+			w.Linef("%s = %s.deserialize_polymorphic(%s)", target, clsName, source)
+		} else {
+			w.Linef("%s = %s.deserialize(%s)", target, clsName, source)
+		}
+
+	case declarations.MapClass, declarations.ListClass, declarations.SetClass:
 		deserializer := nameOfDeserializer(typeDecl)
 		if src.moduleName == "deserialization" {
 			w.ParentherizedWithArguments(fmt.Sprintf("%s = %s", target, deserializer), "", source)
@@ -102,13 +117,14 @@ func writeSingleInlineDeserialization(source, target, description, predicate str
 // Write inline deserialization.
 //
 // Expects the serialized input to be available in a variable named "ser" in
-// the code.
-func writeInlineDeserialization(decls []inlineDeserializationDecl, targetDesc string, w *codeWriter, src *SourceFile) {
+// the code. If trailing is not empty, elements beyond the declared parts are
+// kept in it as a tuple.
+func writeInlineDeserialization(decls []inlineDeserializationDecl, targetDesc, trailing string, w *codeWriter, src *SourceFile) {
 	// Determine the minimum length of the deserialized array.
 	minLength := 0
 
 	for i, decl := range decls {
-		if decl.Type != nil && !decl.Type.Nilable() {
+		if decl.Type != nil && !decl.Type.Nilable() && decl.Default == nil {
 			minLength = i + 1
 		}
 	}
@@ -121,6 +137,10 @@ func writeInlineDeserialization(decls []inlineDeserializationDecl, targetDesc st
 	w.RaiseException("DeserializationError_", fmt.Sprintf("deserialization of %s requires a list or tuple as input", targetDesc))
 	w.Unindent()
 
+	if trailing != "" {
+		w.Linef("%s = tuple(ser[%d:])", trailing, len(decls))
+	}
+
 	if len(decls) == 0 {
 		return
 	}
@@ -138,10 +158,19 @@ func writeInlineDeserialization(decls []inlineDeserializationDecl, targetDesc st
 
 		source := fmt.Sprintf("ser[%d]", i)
 		predicate := ""
-		if decl.Type.Nilable() && i >= minLength {
+		if (decl.Type.Nilable() || decl.Default != nil) && i >= minLength {
 			predicate = fmt.Sprintf("len(ser) > %d", i)
 		}
 
 		writeSingleInlineDeserialization(source, decl.Target, decl.Description, predicate, decl.Type, w, src)
+
+		if decl.Default != nil && predicate != "" {
+			w.Line("else:")
+			w.Indent()
+			w.Linef("%s = %s", decl.Target, valueLiteral(decl.Type, decl.Default, w, src))
+			w.Unindent()
+		}
+
+		writeConstraintChecks(decl.Target, decl.Description, "DeserializationError_", decl.Type, decl.Annotations, w, src)
 	}
 }
@@ -33,10 +33,15 @@ type inlinePackingDecl struct {
 	//
 	// If nil, a nil value is written instead of serialization.
 	Type declarations.Type
+
+	// Annotations declaring constraints on the value.
+	Annotations declarations.Annotations
 }
 
 // Write inline packing for a single type.
 func writeSingleInlinePacking(source, stream, description string, typeDecl declarations.Type, w *codeWriter, src *SourceFile) {
+	typeDecl = declarations.UnderlyingType(typeDecl)
+
 	if typeDecl.Nilable() {
 		w.Linef("if %s is not None:", source)
 		w.Indent()
@@ -48,32 +53,41 @@ func writeSingleInlinePacking(source, stream, description string, typeDecl decla
 		src.ImportAs("entangle.packing", packer, fmt.Sprintf("%s_", packer))
 		w.Linef("%s.write(%s_(%s))", stream, packer, source)
 
-	case declarations.EnumClass, declarations.StructClass:
-		var clsName string
-		if typeDecl.Class() == declarations.StructClass {
-			clsName = typeDecl.(*declarations.StructType).Struct().Name
-		} else {
-			clsName = typeDecl.(*declarations.EnumType).Enum().Name
-		}
+	case declarations.TimestampClass, declarations.DurationClass:
+		packer := nameOfPacker(typeDecl)
 
 		if src.moduleName == "packing" {
-			w.Linef("from .types import %s", clsName)
+			w.Linef("%s(%s, %s)", packer, source, stream)
 		} else {
-			src.Import(".types", clsName)
+			src.ImportAs(".packing", packer, fmt.Sprintf("%s_", packer))
+			w.Linef("%s_(%s, %s)", packer, source, stream)
 		}
 
+	case declarations.EnumClass, declarations.StructClass, declarations.UnionClass:
+		clsName := referenceTypeClass(typeDecl, w, src)
+
 		src.ImportAs("entangle.exceptions", "PackingError", "PackingError_")
 
 		w.Linef("if not isinstance(%s, %s):", source, clsName)
 		w.Indent()
 		w.RaiseException("PackingError_", fmt.Sprintf("%s is not an instance of %s", description, clsName))
 		w.Unindent()
-		w.Linef("%s.pack(%s)", source, stream)
 
-	case declarations.MapClass, declarations.ListClass:
-		requiredType := "list"
-		if typeDecl.Class() == declarations.MapClass {
-			requiredType = "dict"
+		// Polymorphic structs are packed along with their discriminator.
+		if structTypeDecl, ok := typeDecl.(*declarations.StructType); ok && structTypeDecl.Struct().Polymorphic() {
+			w.Linef("%s.pack_polymorphic(%s)", source, stream)
+		} else {
+			w.Linef("%s.pack(%s)", source, stream)
+		}
+
+	case declarations.MapClass, declarations.ListClass, declarations.SetClass:
+		requiredType, requiredDesc := "list", "a list"
+		switch typeDecl.Class() {
+		case declarations.MapClass:
+			requiredType, requiredDesc = "dict", "a map"
+
+		case declarations.SetClass:
+			requiredType, requiredDesc = "(set, frozenset)", "a set"
 		}
 
 		src.ImportAs("entangle.exceptions", "PackingError", "PackingError_")
@@ -82,7 +96,7 @@ func writeSingleInlinePacking(source, stream, description string, typeDecl decla
 
 		w.Linef("if not isinstance(%s, %s):", source, requiredType)
 		w.Indent()
-		w.RaiseException("PackingError_", fmt.Sprintf("%s is not a list", description))
+		w.RaiseException("PackingError_", fmt.Sprintf("%s is not %s", description, requiredDesc))
 		w.Unindent()
 
 		if src.moduleName == "packing" {
@@ -105,7 +119,9 @@ func writeSingleInlinePacking(source, stream, description string, typeDecl decla
 // Write inline packing.
 //
 // Expects the output to be written to a writable called "stream_" in the code.
-func writeInlinePacking(decls []inlinePackingDecl, w *codeWriter, src *SourceFile) {
+// If trailing is not empty, it is the source of already deserialized elements
+// packed after the declared parts.
+func writeInlinePacking(decls []inlinePackingDecl, trailing string, w *codeWriter, src *SourceFile) {
 	// Write None checkers.
 	anyNonNilable := false
 
@@ -124,9 +140,36 @@ func writeInlinePacking(decls []inlinePackingDecl, w *codeWriter, src *SourceFil
 		w.BlankLine()
 	}
 
+	// Write constraint checks.
+	anyConstrained := false
+
+	for _, decl := range decls {
+		if decl.Type == nil || !decl.Annotations.Constraints().Any() {
+			continue
+		}
+
+		src.ImportAs("entangle.exceptions", "PackingError", "PackingError_")
+		writeConstraintChecks(decl.Source, decl.Description, "PackingError_", decl.Type, decl.Annotations, w, src)
+		anyConstrained = true
+	}
+
+	if anyConstrained {
+		w.BlankLine()
+	}
+
 	// Write the array header.
 	src.ImportAs("entangle.packing", "packer", "packer_")
-	w.Linef("stream_.write(packer_.pack_array_header(%d))\n", len(decls))
+	if trailing != "" {
+		length := fmt.Sprintf("len(%s)", trailing)
+		if len(decls) > 0 {
+			length = fmt.Sprintf("%d + %s", len(decls), length)
+		}
+
+		w.ParentherizedWithArguments("stream_.write", "", fmt.Sprintf("packer_.pack_array_header(%s)", length))
+		w.BlankLine()
+	} else {
+		w.Linef("stream_.write(packer_.pack_array_header(%d))\n", len(decls))
+	}
 
 	// Write serialization for each part.
 	for _, decl := range decls {
@@ -137,4 +180,12 @@ func writeInlinePacking(decls []inlinePackingDecl, w *codeWriter, src *SourceFil
 
 		writeSingleInlinePacking(decl.Source, "stream_", decl.Description, decl.Type, w, src)
 	}
+
+	// Write the trailing elements.
+	if trailing != "" {
+		w.Linef("for element_ in %s:", trailing)
+		w.Indent()
+		w.Line("stream_.write(packer_.pack(element_))")
+		w.Unindent()
+	}
 }
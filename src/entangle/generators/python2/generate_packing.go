@@ -42,6 +42,24 @@ func generatePacking(ctx *context) (src *SourceFile, err error) {
 			writeSingleInlinePacking("ser", "stream", "list element", elemType, w, src)
 			w.Unindent()
 
+		case declarations.SetClass:
+			setDecl := typeDecl.(*declarations.SetType)
+			elemType := setDecl.ElementType()
+
+			w.Line("if value is None or not isinstance(value, (set, frozenset)):")
+			w.Indent()
+			w.RaiseException("PackingError_", fmt.Sprintf("cannot pack input as a set"))
+			w.Unindent()
+			w.BlankLine()
+
+			w.Line("stream.write(packer_.pack_array_header(len(value)))")
+			w.BlankLine()
+
+			w.Line("for ser in value:")
+			w.Indent()
+			writeSingleInlinePacking("ser", "stream", "set element", elemType, w, src)
+			w.Unindent()
+
 		case declarations.MapClass:
 			mapDecl := typeDecl.(*declarations.MapType)
 			keyType := mapDecl.KeyType()
@@ -64,6 +82,56 @@ func generatePacking(ctx *context) (src *SourceFile, err error) {
 
 			w.Unindent()
 
+		// Timestamps are packed as MessagePack timestamp extensions in the
+		// smallest format able to represent them.
+		case declarations.TimestampClass:
+			src.ImportAs("datetime", "datetime", "datetime_")
+			src.ImportAs("struct", "pack", "struct_pack_")
+
+			w.Line("if value is None or not isinstance(value, datetime_):")
+			w.Indent()
+			w.RaiseException("PackingError_", "cannot pack input as a timestamp")
+			w.Unindent()
+			w.BlankLine()
+
+			w.Line("if value.utcoffset() is not None:")
+			w.Indent()
+			w.Line("value = value.replace(tzinfo=None) - value.utcoffset()")
+			w.Unindent()
+			w.BlankLine()
+
+			w.Line("delta = value - datetime_(1970, 1, 1)")
+			w.Line("seconds = delta.days * 86400 + delta.seconds")
+			w.Line("nanoseconds = delta.microseconds * 1000")
+			w.BlankLine()
+
+			w.Line("if seconds >> 34 != 0:")
+			w.Indent()
+			w.Line("stream.write(struct_pack_('>BBbIq', 0xc7, 12, -1, nanoseconds, seconds))")
+			w.Unindent()
+			w.Line("elif nanoseconds == 0 and seconds >> 32 == 0:")
+			w.Indent()
+			w.Line("stream.write(struct_pack_('>BbI', 0xd6, -1, seconds))")
+			w.Unindent()
+			w.Line("else:")
+			w.Indent()
+			w.Line("stream.write(struct_pack_('>BbQ', 0xd7, -1, nanoseconds << 34 | seconds))")
+			w.Unindent()
+
+		// Durations are packed as nanoseconds.
+		case declarations.DurationClass:
+			src.ImportAs("datetime", "timedelta", "timedelta_")
+			src.ImportAs("entangle.packing", "pack_int64", "pack_int64_")
+
+			w.Line("if value is None or not isinstance(value, timedelta_):")
+			w.Indent()
+			w.RaiseException("PackingError_", "cannot pack input as a duration")
+			w.Unindent()
+			w.BlankLine()
+
+			w.Line("seconds = value.days * 86400 + value.seconds")
+			w.Line("stream.write(pack_int64_(seconds * 1000000000 + value.microseconds * 1000))")
+
 		default:
 			panic("Cannot generate deserialization code for type")
 		}
@@ -0,0 +1,161 @@
+package python2
+
+import (
+	"entangle/declarations"
+	"fmt"
+)
+
+// Name of the default of initializer arguments of patches.
+//
+// Distinguishes fields not passed to the initializer from fields passed as
+// None.
+const absentName = "_absent"
+
+// Write the default of initializer arguments of patches.
+func writeAbsentDefinition(src *SourceFile) {
+	w := newCodeWriter()
+	w.Linef("%s = object()", absentName)
+	w.Documentation([]string{"Default of absent fields in the initializers of patches."})
+	src.AddBlock(w.Bytes())
+}
+
+// Write the initializer of a patch.
+//
+// Only fields passed to the initializer are present.
+func writePatchInitializer(strct *declarations.Struct, w *codeWriter, src *SourceFile) {
+	args := make([]string, len(strct.Fields)+1)
+	args[0] = "self"
+	for i, field := range strct.FieldsSortedByIndex() {
+		args[i+1] = fmt.Sprintf("%s=%s", snakeCaseString(field.Name), absentName)
+	}
+
+	w.ParentherizedWithArguments("def __init__", ":", args...)
+	w.Indent()
+
+	for _, field := range strct.FieldsSortedByIndex() {
+		w.Linef("if %s is not %s:", snakeCaseString(field.Name), absentName)
+		w.Indent()
+		writeFieldInitialization(strct.Name, field, w, src)
+		w.Unindent()
+	}
+
+	w.Unindent()
+	w.BlankLine()
+}
+
+// Write the presence accessors of a patch.
+//
+// Fields are present once assigned, and absent once deleted.
+func writePatchPresence(strct *declarations.Struct, w *codeWriter) {
+	for _, field := range strct.FieldsSortedByIndex() {
+		name := snakeCaseString(field.Name)
+
+		w.Linef("def has_%s(self):", name)
+		w.Indent()
+		w.Documentation([]string{fmt.Sprintf("Determine if %s is present.", name)})
+		w.Linef("return hasattr(self, '%s')", fieldAttributeName(field))
+		w.Unindent()
+		w.BlankLine()
+	}
+}
+
+// Write the packer of a patch.
+//
+// Patches are packed as maps of field indexes to values, from which absent
+// fields are left out.
+func writePatchPacking(strct *declarations.Struct, w *codeWriter, src *SourceFile) {
+	src.ImportAs("entangle.packing", "packer", "packer_")
+
+	if len(strct.Fields) == 0 {
+		w.Line("stream_.write(packer_.pack_map_header(0))")
+		return
+	}
+
+	attrNames := make([]string, len(strct.Fields))
+	for i, field := range strct.FieldsSortedByIndex() {
+		attrNames[i] = fmt.Sprintf("'%s'", fieldAttributeName(field))
+	}
+
+	// Trailing comma makes a single attribute a tuple.
+	if len(attrNames) == 1 {
+		attrNames[0] += ","
+	}
+
+	w.ParentherizedDefinition("attrs_", attrNames...)
+	w.Line("present_ = [name_ for name_ in attrs_ if hasattr(self, name_)]")
+	w.Line("stream_.write(packer_.pack_map_header(len(present_)))")
+
+	src.ImportAs("entangle.packing", "pack_uint64", "pack_uint64_")
+
+	for _, field := range strct.FieldsSortedByIndex() {
+		source := fmt.Sprintf("self.%s", fieldAttributeName(field))
+		description := fmt.Sprintf("property %s", snakeCaseString(field.Name))
+
+		w.BlankLine()
+		w.Linef("if hasattr(self, '%s'):", fieldAttributeName(field))
+		w.Indent()
+
+		if !field.Type.Nilable() {
+			src.ImportAs("entangle.exceptions", "PackingError", "PackingError_")
+			w.Linef("if %s is None:", source)
+			w.Indent()
+			w.RaiseException("PackingError_", fmt.Sprintf("%s cannot be None", description))
+			w.Unindent()
+		}
+
+		if field.Annotations.Constraints().Any() {
+			src.ImportAs("entangle.exceptions", "PackingError", "PackingError_")
+			writeConstraintChecks(source, description, "PackingError_", field.Type, field.Annotations, w, src)
+		}
+
+		w.Linef("stream_.write(pack_uint64_(%d))", field.Index)
+		writeSingleInlinePacking(source, "stream_", description, field.Type, w, src)
+		w.Unindent()
+	}
+}
+
+// Write the deserialization of a patch.
+//
+// Expects the serialized input to be available in a variable named "ser" and
+// the patch in a variable named "des". Fields unknown to the receiver are
+// ignored, and default values are not applied to absent fields.
+func writePatchDeserialization(strct *declarations.Struct, w *codeWriter, src *SourceFile) {
+	src.ImportAs("entangle.exceptions", "DeserializationError", "DeserializationError_")
+
+	w.Line("if not isinstance(ser, dict):")
+	w.Indent()
+	w.RaiseException("DeserializationError_", fmt.Sprintf("deserialization of %s requires a dict as input", strct.Name))
+	w.Unindent()
+
+	if len(strct.Fields) == 0 {
+		return
+	}
+
+	w.BlankLine()
+	w.Line("for index_, value_ in ser.items():")
+	w.Indent()
+
+	for i, field := range strct.FieldsSortedByIndex() {
+		target := fmt.Sprintf("des.%s", fieldAttributeName(field))
+		description := fmt.Sprintf("property %s", snakeCaseString(field.Name))
+
+		if i == 0 {
+			w.Linef("if index_ == %d:", field.Index)
+		} else {
+			w.Linef("elif index_ == %d:", field.Index)
+		}
+
+		w.Indent()
+
+		// Nil values are present as well.
+		if field.Type.Nilable() {
+			w.Linef("%s = None", target)
+		}
+
+		writeSingleInlineDeserialization("value_", target, description, "", field.Type, w, src)
+		writeConstraintChecks(target, description, "DeserializationError_", field.Type, field.Annotations, w, src)
+		w.Unindent()
+	}
+
+	w.Unindent()
+}
@@ -0,0 +1,69 @@
+package python2
+
+import (
+	"entangle/declarations"
+	"fmt"
+)
+
+// Write the polymorphic packer of a struct.
+//
+// The struct is packed as an array of its name, as the discriminator, and
+// the packed struct.
+func writePolymorphicPacking(strct *declarations.Struct, w *codeWriter, src *SourceFile) {
+	src.ImportAs("entangle.packing", "packer", "packer_")
+	src.ImportAs("entangle.packing", "pack_string", "pack_string_")
+
+	w.Line("def pack_polymorphic(self, stream_):")
+	w.Indent()
+	w.Line(`"""Pack along with the discriminator of the struct.`)
+	w.BlankLine()
+	w.Line(`:param stream_: Stream to pack the type to.`)
+	w.Line(`:raises entangle.PackingError:`)
+	w.Line(`    if the data structure could not be packed.`)
+	w.Line(`"""`)
+	w.BlankLine()
+	w.Line("stream_.write(packer_.pack_array_header(2))")
+	w.Linef("stream_.write(pack_string_('%s'))", strct.Name)
+	w.Line("self.pack(stream_)")
+	w.Unindent()
+}
+
+// Write the polymorphic deserializer of a struct.
+//
+// The deserializer dispatches to the struct or any struct inheriting from it
+// by the discriminator.
+func writePolymorphicDeserialization(strct *declarations.Struct, w *codeWriter, src *SourceFile) {
+	src.ImportAs("entangle.exceptions", "DeserializationError", "DeserializationError_")
+
+	w.Line("@classmethod")
+	w.Line("def deserialize_polymorphic(cls, ser):")
+	w.Indent()
+	w.Linef(`"""Deserialize %s or any struct inheriting from it.`, strct.Name)
+	w.BlankLine()
+	w.Line(`:returns: an instance of the class named by the discriminator.`)
+	w.Line(`:raises entangle.DeserializationError:`)
+	w.Line(`    if the serialized input could not be deserialized.`)
+	w.Line(`"""`)
+	w.BlankLine()
+
+	w.Line("if not isinstance(ser, (list, tuple)):")
+	w.Indent()
+	w.RaiseException("DeserializationError_", fmt.Sprintf("deserialization of %s requires a list or tuple as input", strct.Name))
+	w.Unindent()
+	w.Line("if len(ser) != 2:")
+	w.Indent()
+	w.RaiseException("DeserializationError_", fmt.Sprintf("%s must hold exactly a discriminator and a value", strct.Name))
+	w.Unindent()
+	w.BlankLine()
+
+	for _, s := range append([]*declarations.Struct{strct}, strct.Descendants()...) {
+		w.Linef("if ser[0] == '%s':", s.Name)
+		w.Indent()
+		w.Linef("return %s.deserialize(ser[1])", s.Name)
+		w.Unindent()
+	}
+
+	w.BlankLine()
+	w.RaiseException("DeserializationError_", fmt.Sprintf("unknown discriminator for %s", strct.Name))
+	w.Unindent()
+}
@@ -2,8 +2,14 @@ package python2
 
 import (
 	"fmt"
+	"entangle/declarations"
 )
 
+// Name of the attribute keeping trailing elements unknown to a struct.
+//
+// The trailing underscore prevents collisions with attributes of fields.
+const unknownElementsName = "_unknown_elements_"
+
 // Generate types.py.
 func generateTypes(ctx *context) (src *SourceFile, err error) {
 	src = NewSourceFile("types")
@@ -13,55 +19,108 @@ func generateTypes(ctx *context) (src *SourceFile, err error) {
 		src.Export(enum.Name)
 		buffer := new(safeBuffer)
 
-		// Write the enum class definition.
-		src.ImportAs("entangle.types", "Enum", "Enum_")
-		buffer.WriteString(fmt.Sprintf("class %s(Enum_):\n", enum.Name))
+		// Write the enum class definition. Flags derive from an integer
+		// flag class, of which values can be combined, and open enums from
+		// classes preserving unknown values.
+		base := enumBaseClass(enum)
+		src.ImportAs("entangle.types", base, fmt.Sprintf("%s_", base))
+		buffer.WriteString(fmt.Sprintf("class %s(%s_):\n", enum.Name, base))
+
+		writeDocumentation(buffer, declarationDocumentation(enum.Documentation, enum.Annotations), 1)
 
-		writeDocumentation(buffer, enum.Documentation, 1)
+		// Deprecated values are accessed through the metaclass.
+		if metaclass := writeEnumMetaclass(enum, src); metaclass != "" {
+			buffer.WriteString(fmt.Sprintf("    __metaclass__ = %s\n\n", metaclass))
+		}
 
 		// Write each value.
 		for _, value := range enum.ValuesSortedByValue() {
 			buffer.WriteString(fmt.Sprintf("    %s = %d\n", value.Name, value.Value))
-			writeDocumentation(buffer, value.Documentation, 1)
+			writeDocumentation(buffer, declarationDocumentation(value.Documentation, value.Annotations), 1)
 		}
 
 		if len(enum.Values) == 0 {
 			buffer.WriteString("    pass")
 		}
 
+		// Unknown values are replaced by the fallback value.
+		if fallback := enum.Fallback(); fallback != nil {
+			src.ImportAs("entangle.exceptions", "DeserializationError", "DeserializationError_")
+
+			buffer.WriteString("\n    @classmethod\n")
+			buffer.WriteString("    def deserialize(cls, value):\n")
+			buffer.WriteString("        try:\n")
+			buffer.WriteString(fmt.Sprintf("            return super(%s, cls).deserialize(value)\n", enum.Name))
+			buffer.WriteString("        except DeserializationError_:\n")
+			buffer.WriteString(fmt.Sprintf("            return cls.%s\n", fallback.Name))
+		}
+
 		src.AddBlock(buffer.Bytes())
 	}
 
 	// Generate structs.
-	for _, strct := range ctx.Interface.Structs {
+	absentDefined := false
+
+	for _, strct := range ctx.Interface.StructsSortedByInheritance() {
 		src.Export(strct.Name)
 		w := newCodeWriter()
 
-		// Write the enum class definition.
-		w.Linef("class %s(object):", strct.Name)
+		// Initializers of patches tell absent fields by a default, which has
+		// to be defined ahead of the patches.
+		if strct.Patch() && !absentDefined {
+			writeAbsentDefinition(src)
+			absentDefined = true
+		}
+
+		// Write the struct class definition. Structs in polymorphic
+		// hierarchies derive from their parent.
+		inheriting := strct.Polymorphic() && strct.Parent != nil
+
+		if inheriting {
+			w.Linef("class %s(%s):", strct.Name, strct.Parent.Name)
+		} else {
+			w.Linef("class %s(object):", strct.Name)
+		}
 		w.Indent()
-		w.Documentation(strct.Documentation)
+		w.Documentation(declarationDocumentation(strct.Documentation, strct.Annotations))
 
 		// Build names.
 		pyNameMapping := make(map[string]string, len(strct.Fields))
+		attrNameMapping := make(map[string]string, len(strct.Fields))
 		for _, field := range strct.Fields {
 			pyNameMapping[field.Name] = snakeCaseString(field.Name)
+			attrNameMapping[field.Name] = fieldAttributeName(field)
 		}
 
-		// Write the slots.
+		// Write the slots. Slots of inherited fields are declared by the
+		// parent of inheriting classes.
 		fieldNames := make([]string, len(strct.Fields))
+		attrNames := make([]string, 0, len(strct.Fields) + 1)
 		i := 0
 
 		for _, field := range strct.Fields {
 			fieldNames[i] = pyNameMapping[field.Name]
+			if !inheriting || !strct.Parent.FieldNameInUse(field.Name) {
+				attrNames = append(attrNames, attrNameMapping[field.Name])
+			}
 			i++
 		}
 
-		w.ParentherizedDefinition("__slots__", stringifyStrings(fieldNames)...)
+		// Trailing elements unknown to this version of the struct are kept
+		// so that packing a deserialized struct is lossless. The slot is
+		// declared by the first struct of the hierarchy preserving them.
+		preserving := strct.PreservesUnknown()
+		if preserving && (!inheriting || !strct.Parent.PreservesUnknown()) {
+			attrNames = append(attrNames, unknownElementsName)
+		}
+
+		w.ParentherizedDefinition("__slots__", stringifyStrings(attrNames)...)
 		w.BlankLine()
 
 		// Write the initializer.
-		if len(fieldNames) > 0 {
+		if strct.Patch() && len(fieldNames) > 0 {
+			writePatchInitializer(strct, w, src)
+		} else if len(fieldNames) > 0 || preserving {
 			nonedArgs := make([]string, len(fieldNames) + 1)
 			nonedArgs[0] = "self"
 			for i, n := range fieldNames {
@@ -72,14 +131,26 @@ func generateTypes(ctx *context) (src *SourceFile, err error) {
 
 			w.Indent()
 
-			for _, name := range fieldNames {
-				w.Linef("self.%s = %s", name, name)
+			for _, field := range strct.Fields {
+				writeFieldInitialization(strct.Name, field, w, src)
+			}
+
+			if preserving {
+				w.Linef("self.%s = ()", unknownElementsName)
 			}
 
 			w.Unindent()
 			w.BlankLine()
 		}
 
+		// Write the properties of deprecated fields.
+		writeDeprecatedFieldProperties(strct.Name, strct.FieldsSortedByIndex(), w, src)
+
+		// Write the presence accessors of patches.
+		if strct.Patch() {
+			writePatchPresence(strct, w)
+		}
+
 		// Write the packer.
 		w.Line("def pack(self, stream_):")
 		w.Indent()
@@ -91,19 +162,27 @@ func generateTypes(ctx *context) (src *SourceFile, err error) {
 		w.Line(`"""`)
 		w.BlankLine()
 
-		decls := make([]inlinePackingDecl, strct.SerializedLength())
-		for _, field := range strct.Fields {
-			decls[field.Index - 1] = inlinePackingDecl {
-				Source: fmt.Sprintf("self.%s", pyNameMapping[field.Name]),
-				Description: fmt.Sprintf("property %s", pyNameMapping[field.Name]),
-				Type: field.Type,
+		if strct.Patch() {
+			writePatchPacking(strct, w, src)
+			w.Unindent()
+			w.BlankLine()
+		} else {
+			decls := make([]inlinePackingDecl, strct.SerializedLength())
+			for _, field := range strct.Fields {
+				decls[field.Index - 1] = inlinePackingDecl {
+					Source: fmt.Sprintf("self.%s", attrNameMapping[field.Name]),
+					Description: fmt.Sprintf("property %s", pyNameMapping[field.Name]),
+					Type: field.Type,
+					Annotations: field.Annotations,
+				}
 			}
-		}
-		writeInlinePacking(decls, w, src)
-
-		w.Unindent()
+			trailing := ""
+			if preserving {
+				trailing = "self." + unknownElementsName
+			}
+			writeInlinePacking(decls, trailing, w, src)
 
-		if len(decls) > 0 {
+			w.Unindent()
 			w.BlankLine()
 		}
 
@@ -120,23 +199,78 @@ func generateTypes(ctx *context) (src *SourceFile, err error) {
 		w.Line("des = cls()")
 		w.BlankLine()
 
-		desDecls := make([]inlineDeserializationDecl, strct.SerializedLength())
-		for _, field := range strct.Fields {
-			desDecls[field.Index - 1] = inlineDeserializationDecl {
-				Target: fmt.Sprintf("des.%s", pyNameMapping[field.Name]),
-				Description: fmt.Sprintf("property %s", pyNameMapping[field.Name]),
-				Type: field.Type,
+		if strct.Patch() {
+			writePatchDeserialization(strct, w, src)
+		} else {
+			desDecls := make([]inlineDeserializationDecl, strct.SerializedLength())
+			for _, field := range strct.Fields {
+				desDecls[field.Index - 1] = inlineDeserializationDecl {
+					Target: fmt.Sprintf("des.%s", attrNameMapping[field.Name]),
+					Description: fmt.Sprintf("property %s", pyNameMapping[field.Name]),
+					Type: field.Type,
+					Default: field.Default,
+					Annotations: field.Annotations,
+				}
 			}
+			trailing := ""
+			if preserving {
+				trailing = "des." + unknownElementsName
+			}
+			writeInlineDeserialization(desDecls, strct.Name, trailing, w, src)
 		}
-		writeInlineDeserialization(desDecls, strct.Name, w, src)
 
 		w.BlankLine()
 		w.Line("return des")
 
 		w.Unindent()
 
+		// Write the polymorphic packer and deserializer.
+		if strct.Polymorphic() {
+			w.BlankLine()
+			writePolymorphicPacking(strct, w, src)
+			w.BlankLine()
+			writePolymorphicDeserialization(strct, w, src)
+		}
+
+		src.AddBlock(w.Bytes())
+	}
+
+	// Generate unions.
+	for _, union := range ctx.Interface.UnionsSortedByName() {
+		writeUnion(union, src)
+	}
+
+	// Generate type aliases.
+	for _, typedef := range ctx.Interface.TypedefsSortedByName() {
+		src.Export(typedef.Name)
+		w := newCodeWriter()
+
+		aliased := aliasedTypeName(typedef.Type, src)
+
+		docs := make([]string, 0, len(typedef.Documentation)+1)
+		docs = append(docs, typedef.Documentation...)
+		docs = append(docs, fmt.Sprintf("Alias of :class:`%s`.", aliased))
+		docs = declarationDocumentation(docs, typedef.Annotations)
+
+		w.Linef("%s = %s", typedef.Name, aliased)
+		w.Documentation(docs)
+
 		src.AddBlock(w.Bytes())
 	}
 
 	return
 }
+
+// Name of the runtime base class of an enumeration.
+func enumBaseClass(enum *declarations.Enum) string {
+	base := "Enum"
+	if enum.Flags {
+		base = "Flags"
+	}
+
+	if enum.Open() {
+		return fmt.Sprintf("Open%s", base)
+	}
+
+	return base
+}
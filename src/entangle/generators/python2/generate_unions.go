@@ -0,0 +1,118 @@
+package python2
+
+import (
+	"entangle/declarations"
+	"fmt"
+)
+
+// Write the classes of a union.
+//
+// The union is written as a base class responsible for deserialization, with
+// a subclass for each member holding the member value.
+func writeUnion(union *declarations.Union, src *SourceFile) {
+	members := union.FieldsSortedByIndex()
+
+	// Write the base class.
+	src.Export(union.Name)
+	src.ImportAs("entangle.exceptions", "DeserializationError", "DeserializationError_")
+
+	w := newCodeWriter()
+	w.Linef("class %s(object):", union.Name)
+	w.Indent()
+	w.Documentation(declarationDocumentation(union.Documentation, union.Annotations))
+
+	w.Line("__slots__ = ()")
+	w.BlankLine()
+
+	w.Line("@classmethod")
+	w.Line("def deserialize(cls, ser):")
+	w.Indent()
+	w.Line(`"""Deserialize.`)
+	w.BlankLine()
+	w.Line(`:returns: an instance of the class of the member held by the union.`)
+	w.Line(`:raises entangle.DeserializationError:`)
+	w.Line(`    if the serialized input could not be deserialized.`)
+	w.Line(`"""`)
+	w.BlankLine()
+
+	w.Line("if not isinstance(ser, (list, tuple)):")
+	w.Indent()
+	w.RaiseException("DeserializationError_", fmt.Sprintf("deserialization of %s requires a list or tuple as input", union.Name))
+	w.Unindent()
+	w.Line("if len(ser) != 2:")
+	w.Indent()
+	w.RaiseException("DeserializationError_", fmt.Sprintf("%s must hold exactly one member", union.Name))
+	w.Unindent()
+	w.BlankLine()
+
+	for _, member := range members {
+		memberClsName := fmt.Sprintf("%s%s", union.Name, member.Name)
+
+		w.Linef("if ser[0] == %d:", member.Index)
+		w.Indent()
+		w.Linef("des = %s()", memberClsName)
+		writeSingleInlineDeserialization("ser[1]", "des.value", fmt.Sprintf("member %s", member.Name), "", member.Type, w, src)
+		writeConstraintChecks("des.value", fmt.Sprintf("member %s", member.Name), "DeserializationError_", member.Type, member.Annotations, w, src)
+		w.Line("return des")
+		w.Unindent()
+	}
+
+	w.BlankLine()
+	w.RaiseException("DeserializationError_", fmt.Sprintf("unknown member index for %s", union.Name))
+	w.Unindent()
+	w.Unindent()
+
+	src.AddBlock(w.Bytes())
+
+	// Write a class for each member.
+	for _, member := range members {
+		memberClsName := fmt.Sprintf("%s%s", union.Name, member.Name)
+		src.Export(memberClsName)
+
+		w := newCodeWriter()
+		w.Linef("class %s(%s):", memberClsName, union.Name)
+		w.Indent()
+
+		docs := make([]string, 0, len(member.Documentation)+1)
+		docs = append(docs, fmt.Sprintf("%s member of :class:`%s`.", member.Name, union.Name))
+		docs = append(docs, member.Documentation...)
+		w.Documentation(declarationDocumentation(docs, member.Annotations))
+
+		w.Linef("index = %d", member.Index)
+		w.BlankLine()
+		w.Line("__slots__ = ('value',)")
+		w.BlankLine()
+
+		w.Line("def __init__(self, value=None):")
+		w.Line("    self.value = value")
+		w.BlankLine()
+
+		// Write the packer.
+		src.ImportAs("entangle.exceptions", "PackingError", "PackingError_")
+		src.ImportAs("entangle.packing", "packer", "packer_")
+
+		w.Line("def pack(self, stream_):")
+		w.Indent()
+		w.Line(`"""Pack.`)
+		w.BlankLine()
+		w.Line(`:param stream_: Stream to pack the type to.`)
+		w.Line(`:raises entangle.PackingError:`)
+		w.Line(`    if the data structure could not be packed.`)
+		w.Line(`"""`)
+		w.BlankLine()
+		w.Line("if self.value is None:")
+		w.Line("    raise PackingError_('value cannot be None')")
+		w.BlankLine()
+
+		if member.Annotations.Constraints().Any() {
+			writeConstraintChecks("self.value", "value", "PackingError_", member.Type, member.Annotations, w, src)
+			w.BlankLine()
+		}
+		w.Line("stream_.write(packer_.pack_array_header(2))")
+		writeSingleInlinePacking("self.index", "stream_", "index", declarations.Uint64Type, w, src)
+		writeSingleInlinePacking("self.value", "stream_", "value", member.Type, w, src)
+		w.Unindent()
+
+		src.AddBlock(w.Bytes())
+	}
+}
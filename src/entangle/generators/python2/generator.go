@@ -37,6 +37,7 @@ func (g *generator) Generate(interfaceDecl *declarations.Interface, outputPath s
 	} {
 		{ "__init__.py", generateInit },
 		{ "types.py", generateTypes },
+		{ "constants.py", generateConstants },
 		{ "clients.py", generateClients },
 		{ "exceptions.py", generateExceptions },
 		{ "deserialization.py", generateDeserialization },
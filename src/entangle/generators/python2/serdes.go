@@ -7,24 +7,28 @@ import (
 
 var (
 	deserializationSubTypeMapping = map[declarations.TypeClass]string{
-		declarations.BoolClass:    "bool",
-		declarations.StringClass:  "string",
-		declarations.BinaryClass:  "binary",
-		declarations.Float32Class: "float32",
-		declarations.Float64Class: "float64",
-		declarations.Int8Class:    "int8",
-		declarations.Int16Class:   "int16",
-		declarations.Int32Class:   "int32",
-		declarations.Int64Class:   "int64",
-		declarations.Uint8Class:   "uint8",
-		declarations.Uint16Class:  "uint16",
-		declarations.Uint32Class:  "uint32",
-		declarations.Uint64Class:  "uint64",
+		declarations.BoolClass:      "bool",
+		declarations.StringClass:    "string",
+		declarations.BinaryClass:    "binary",
+		declarations.Float32Class:   "float32",
+		declarations.Float64Class:   "float64",
+		declarations.Int8Class:      "int8",
+		declarations.Int16Class:     "int16",
+		declarations.Int32Class:     "int32",
+		declarations.Int64Class:     "int64",
+		declarations.Uint8Class:     "uint8",
+		declarations.Uint16Class:    "uint16",
+		declarations.Uint32Class:    "uint32",
+		declarations.Uint64Class:    "uint64",
+		declarations.TimestampClass: "timestamp",
+		declarations.DurationClass:  "duration",
 	}
 )
 
 // Name of subtype for deserializer for type.
 func nameOfDeserializerSubtype(typeDecl declarations.Type) string {
+	typeDecl = declarations.UnderlyingType(typeDecl)
+
 	nilable := ""
 	if typeDecl.Nilable() {
 		nilable = "nilable_"
@@ -36,12 +40,18 @@ func nameOfDeserializerSubtype(typeDecl declarations.Type) string {
 
 	switch typeDecl.Class() {
 	case declarations.EnumClass:
-		return fmt.Sprintf("%s%s", nilable, typeDecl.(*declarations.EnumType).Enum().Name)
+		enumTypeDecl := typeDecl.(*declarations.EnumType)
+		return fmt.Sprintf("%s%s%s", nilable, importSubtypePrefix(enumTypeDecl.Import()), enumTypeDecl.Enum().Name)
 
 	case declarations.StructClass:
-		return fmt.Sprintf("%s%s", nilable, typeDecl.(*declarations.StructType).Struct().Name)
+		structTypeDecl := typeDecl.(*declarations.StructType)
+		return fmt.Sprintf("%s%s%s", nilable, importSubtypePrefix(structTypeDecl.Import()), structTypeDecl.Struct().Name)
+
+	case declarations.UnionClass:
+		unionTypeDecl := typeDecl.(*declarations.UnionType)
+		return fmt.Sprintf("%s%s%s", nilable, importSubtypePrefix(unionTypeDecl.Import()), unionTypeDecl.Union().Name)
 
-	case declarations.MapClass, declarations.ListClass:
+	case declarations.MapClass, declarations.ListClass, declarations.SetClass:
 		return fmt.Sprintf("%s%s", nilable, suffixOfSerDes(typeDecl))
 
 	default:
@@ -49,10 +59,21 @@ func nameOfDeserializerSubtype(typeDecl declarations.Type) string {
 	}
 }
 
+// Prefix of subtype names for declarations from an imported definition.
+func importSubtypePrefix(importDecl *declarations.Import) string {
+	if importDecl == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s_", importDecl.Name)
+}
+
 // Prefix of deserializer for type.
 //
 // Returns an empty value if the type does not need a custom deserializer.
 func suffixOfSerDes(typeDecl declarations.Type) string {
+	typeDecl = declarations.UnderlyingType(typeDecl)
+
 	switch typeDecl.Class() {
 	case declarations.MapClass:
 		mapTypeDecl := typeDecl.(*declarations.MapType)
@@ -67,6 +88,15 @@ func suffixOfSerDes(typeDecl declarations.Type) string {
 
 		return fmt.Sprintf("list_of_%s", nameOfDeserializerSubtype(elementTypeDecl))
 
+	case declarations.SetClass:
+		setTypeDecl := typeDecl.(*declarations.SetType)
+		elementTypeDecl := setTypeDecl.ElementType()
+
+		return fmt.Sprintf("set_of_%s", nameOfDeserializerSubtype(elementTypeDecl))
+
+	case declarations.TimestampClass, declarations.DurationClass:
+		return deserializationSubTypeMapping[typeDecl.Class()]
+
 	default:
 		return ""
 	}
@@ -100,6 +130,8 @@ func nameOfPacker(typeDecl declarations.Type) string {
 
 // Map a type to a serialization/deserialization map.
 func mapTypeToSerDesMap(typeDecl declarations.Type, m *map[string]declarations.Type) {
+	typeDecl = declarations.UnderlyingType(typeDecl)
+
 	suffix := suffixOfSerDes(typeDecl)
 	if suffix == "" {
 		return
@@ -112,21 +144,16 @@ func mapTypeToSerDesMap(typeDecl declarations.Type, m *map[string]declarations.T
 	switch typeDecl.Class() {
 	case declarations.MapClass:
 		mapTypeDecl := typeDecl.(*declarations.MapType)
-		valueTypeDecl := mapTypeDecl.ValueType()
-
-		switch valueTypeDecl.Class() {
-		case declarations.MapClass, declarations.ListClass:
-			mapTypeToSerDesMap(valueTypeDecl, m)
-		}
+		mapTypeToSerDesMap(mapTypeDecl.KeyType(), m)
+		mapTypeToSerDesMap(mapTypeDecl.ValueType(), m)
 
 	case declarations.ListClass:
 		listTypeDecl := typeDecl.(*declarations.ListType)
-		elementTypeDecl := listTypeDecl.ElementType()
+		mapTypeToSerDesMap(listTypeDecl.ElementType(), m)
 
-		switch elementTypeDecl.Class() {
-		case declarations.MapClass, declarations.ListClass:
-			mapTypeToSerDesMap(elementTypeDecl, m)
-		}
+	case declarations.SetClass:
+		setTypeDecl := typeDecl.(*declarations.SetType)
+		mapTypeToSerDesMap(setTypeDecl.ElementType(), m)
 	}
 
 	(*m)[suffix] = typeDecl
@@ -157,5 +184,19 @@ func buildSerDesMap(interfaceDecl *declarations.Interface) (m map[string]declara
 		}
 	}
 
+	// Iterate across all exception fields.
+	for _, excDecl := range interfaceDecl.Exceptions {
+		for _, field := range excDecl.Fields {
+			mapTypeToSerDesMap(field.Type, &m)
+		}
+	}
+
+	// Iterate across all union members.
+	for _, unionDecl := range interfaceDecl.Unions {
+		for _, field := range unionDecl.Fields {
+			mapTypeToSerDesMap(field.Type, &m)
+		}
+	}
+
 	return
 }
@@ -0,0 +1,84 @@
+package python2
+
+import (
+	"entangle/declarations"
+	"fmt"
+)
+
+// Python types of simple types.
+var simpleAliasedTypeMapping = map[declarations.TypeClass]string{
+	declarations.BoolClass:    "bool",
+	declarations.StringClass:  "unicode",
+	declarations.BinaryClass:  "str",
+	declarations.Float32Class: "float",
+	declarations.Float64Class: "float",
+	declarations.Int8Class:    "int",
+	declarations.Int16Class:   "int",
+	declarations.Int32Class:   "int",
+	declarations.Int64Class:   "long",
+	declarations.Uint8Class:   "int",
+	declarations.Uint16Class:  "int",
+	declarations.Uint32Class:  "long",
+	declarations.Uint64Class:  "long",
+}
+
+// Name of the Python type a type alias refers to.
+//
+// Type aliases are resolved to the Python type of the underlying type, as
+// aliases are not guaranteed to be declared before the aliases using them.
+func aliasedTypeName(typeDecl declarations.Type, src *SourceFile) string {
+	typeDecl = declarations.UnderlyingType(typeDecl)
+
+	if name, ok := simpleAliasedTypeMapping[typeDecl.Class()]; ok {
+		return name
+	}
+
+	var clsName string
+	var importDecl *declarations.Import
+
+	switch typeDecl.Class() {
+	case declarations.TimestampClass:
+		src.ImportAs("datetime", "datetime", "datetime_")
+		return "datetime_"
+
+	case declarations.DurationClass:
+		src.ImportAs("datetime", "timedelta", "timedelta_")
+		return "timedelta_"
+
+	case declarations.MapClass:
+		return "dict"
+
+	case declarations.ListClass:
+		return "list"
+
+	case declarations.SetClass:
+		return "set"
+
+	case declarations.StructClass:
+		structTypeDecl := typeDecl.(*declarations.StructType)
+		clsName = structTypeDecl.Struct().Name
+		importDecl = structTypeDecl.Import()
+
+	case declarations.UnionClass:
+		unionTypeDecl := typeDecl.(*declarations.UnionType)
+		clsName = unionTypeDecl.Union().Name
+		importDecl = unionTypeDecl.Import()
+
+	case declarations.EnumClass:
+		enumTypeDecl := typeDecl.(*declarations.EnumType)
+		clsName = enumTypeDecl.Enum().Name
+		importDecl = enumTypeDecl.Import()
+
+	default:
+		panic("Unimplemented type")
+	}
+
+	// Classes local to the definition are declared ahead of the aliases.
+	if importDecl == nil {
+		return clsName
+	}
+
+	alias := fmt.Sprintf("%s_%s", importDecl.Name, clsName)
+	src.ImportAs(fmt.Sprintf("%s.types", importDecl.Interface.Name), clsName, alias)
+	return alias
+}
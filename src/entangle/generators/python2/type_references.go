@@ -0,0 +1,51 @@
+package python2
+
+import (
+	"entangle/declarations"
+	"fmt"
+)
+
+// Reference the class of a struct, union or enumeration type.
+//
+// Makes sure that the class is imported in the source file and returns the
+// name by which the class can be referenced. Classes local to the definition
+// are imported inline in the packing and deserialization modules to avoid
+// circular imports.
+func referenceTypeClass(typeDecl declarations.Type, w *codeWriter, src *SourceFile) string {
+	var clsName string
+	var importDecl *declarations.Import
+
+	switch typeDecl.Class() {
+	case declarations.StructClass:
+		structTypeDecl := typeDecl.(*declarations.StructType)
+		clsName = structTypeDecl.Struct().Name
+		importDecl = structTypeDecl.Import()
+
+	case declarations.UnionClass:
+		unionTypeDecl := typeDecl.(*declarations.UnionType)
+		clsName = unionTypeDecl.Union().Name
+		importDecl = unionTypeDecl.Import()
+
+	case declarations.EnumClass:
+		enumTypeDecl := typeDecl.(*declarations.EnumType)
+		clsName = enumTypeDecl.Enum().Name
+		importDecl = enumTypeDecl.Import()
+
+	default:
+		panic("Type does not have a class")
+	}
+
+	if importDecl != nil {
+		alias := fmt.Sprintf("%s_%s", importDecl.Name, clsName)
+		src.ImportAs(fmt.Sprintf("%s.types", importDecl.Interface.Name), clsName, alias)
+		return alias
+	}
+
+	if src.moduleName == "packing" || src.moduleName == "deserialization" {
+		w.Linef("from .types import %s", clsName)
+	} else {
+		src.Import(".types", clsName)
+	}
+
+	return clsName
+}
@@ -33,3 +33,15 @@ func TestIdentifierTokenType(t *testing.T) {
 	assertValidIdentifierTokenType(t, "typedef", token.Typedef)
 	assertValidIdentifierTokenType(t, "service", token.Service)
 }
+
+func TestContextualKeywordTokenType(t *testing.T) {
+	// Check that contextual keywords are lexed as identifiers.
+	assertValidIdentifierTokenType(t, "flags", token.Identifier)
+	assertValidIdentifierTokenType(t, "set", token.Identifier)
+	assertValidIdentifierTokenType(t, "timestamp", token.Identifier)
+	assertValidIdentifierTokenType(t, "duration", token.Identifier)
+	assertValidIdentifierTokenType(t, "oneway", token.Identifier)
+	assertValidIdentifierTokenType(t, "throws", token.Identifier)
+	assertValidIdentifierTokenType(t, "union", token.Identifier)
+	assertValidIdentifierTokenType(t, "reserved", token.Identifier)
+}
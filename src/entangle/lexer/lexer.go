@@ -7,6 +7,7 @@ import (
 	"entangle/token"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 var (
@@ -15,6 +16,7 @@ var (
 	errUnexpectedEndOfLineLiteralDesc = "unexpected end of line in literal"
 	errUnexpectedEndOfLineNumberDesc  = "unexpected end of line in numerical"
 	errNumberOutOfRangeDesc           = "number is out of range"
+	errInvalidDurationDesc            = "invalid duration"
 )
 
 const (
@@ -120,6 +122,11 @@ func isDigit(r rune) bool {
 	return r >= '0' && r <= '9'
 }
 
+// Test if a rune is part of a duration unit.
+func isDurationUnitCharacter(r rune) bool {
+	return r == 'n' || r == 'u' || r == 'µ' || r == 'm' || r == 's' || r == 'h'
+}
+
 // Test if a rune is a hexadecimal digit.
 func isHexadecimalDigit(r rune) bool {
 	return r > 0 && r < 128 && hexDigitCharacterTable[r]
@@ -186,6 +193,16 @@ func (l *Lexer) skipSingleLineComment() {
 	}
 }
 
+// Skip the rest of the current line.
+//
+// Allows recovering from an error, after which the next token is the new line
+// ending the line or the end of file.
+func (l *Lexer) SkipLine() {
+	for l.cur != eof && l.cur != eol {
+		l.next()
+	}
+}
+
 // Error indicating that the current character was unexpected.
 func (l *Lexer) unexpectedCharacter() (t token.Token, err error) {
 	err = l.parseErrorHere(errUnexpectedCharacterDesc)
@@ -304,6 +321,11 @@ func (l *Lexer) parseNumber() (t token.Token, err error) {
 			return
 		}
 
+		// A unit following the digits makes for a duration.
+		if isDurationUnitCharacter(l.cur) {
+			return l.parseDuration(t, dataStart)
+		}
+
 		// Make sure that we've hit a valid delimiter.
 		if !isValidDelimiter(l.cur) {
 			return l.unexpectedCharacter()
@@ -404,6 +426,32 @@ func (l *Lexer) parseNumber() (t token.Token, err error) {
 	return
 }
 
+// Parse a duration.
+//
+// Invoked with the first unit character following the digits of the first
+// part of the duration as the current character. Durations are sequences of
+// decimal integers followed by units, e.g. 1h30m or 250ms.
+func (l *Lexer) parseDuration(t token.Token, dataStart int) (token.Token, error) {
+	for isDigit(l.cur) || isDurationUnitCharacter(l.cur) {
+		l.next()
+	}
+
+	if !isValidDelimiter(l.cur) {
+		return l.unexpectedCharacter()
+	}
+
+	t.StringValue = l.stringUntilHere(dataStart)
+	t.End = l.position.Before()
+	t.Type = token.DurationConstant
+
+	var err error
+	if t.DurationValue, err = time.ParseDuration(t.StringValue); err != nil {
+		return t, l.parseError(errInvalidDurationDesc, t.Start, t.End)
+	}
+
+	return t, nil
+}
+
 // Parse a quoted string.
 func (l *Lexer) parseQuotedString() (t token.Token, err error) {
 	escaped := false
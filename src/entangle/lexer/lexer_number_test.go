@@ -4,6 +4,7 @@ import (
 	"entangle/token"
 	"fmt"
 	"testing"
+	"time"
 )
 
 func testValidUintConstant(t *testing.T, stringSrc string, value uint64) {
@@ -78,6 +79,30 @@ func testValidFloatConstant(t *testing.T, stringSrc string, value float64) {
 	}
 }
 
+func testValidDurationConstant(t *testing.T, stringSrc string, value time.Duration) {
+	for _, delFixture := range delimiterFixtures {
+		if delFixture.src == "." {
+			continue
+		}
+
+		fixtureSrc := fmt.Sprintf("%s%s", stringSrc, delFixture.src)
+
+		tokens := testValidWithFirstOfType(t, fixtureSrc, token.DurationConstant)
+		if tokens == nil {
+			continue
+		}
+
+		tok := &tokens[0]
+		if tok.DurationValue != value {
+			t.Fatalf("Expected token duration value lexed from `%s` to be %v, but it is %v", fixtureSrc, value, tok.DurationValue)
+		}
+
+		if tokens[1].Type != delFixture.tokenType {
+			t.Errorf("Expected token following duration constant when lexing `%s` to be of type %s but it is of type %s", fixtureSrc, delFixture.tokenType, tokens[1].Type)
+		}
+	}
+}
+
 func TestLexerHexadecimalConstant(t *testing.T) {
 	testValidUintConstant(t, "0x1", 1)
 	testValidUintConstant(t, "0x0123456789abcdef", 0x0123456789abcdef)
@@ -107,3 +132,13 @@ func TestLexerFloatConstant(t *testing.T) {
 	testValidFloatConstant(t, "-00123456789.0123456789e123", -123456789.0123456789e123)
 	testValidFloatConstant(t, "00123456789.0123456789e123", 123456789.0123456789e123)
 }
+
+func TestLexerDurationConstant(t *testing.T) {
+	testValidDurationConstant(t, "5s", 5*time.Second)
+	testValidDurationConstant(t, "250ms", 250*time.Millisecond)
+	testValidDurationConstant(t, "1h30m", 90*time.Minute)
+	testValidDurationConstant(t, "-10us", -10*time.Microsecond)
+
+	assertLexerError(t, "5x", errUnexpectedCharacterDesc)
+	assertLexerError(t, "5mm", errInvalidDurationDesc)
+}
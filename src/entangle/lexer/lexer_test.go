@@ -28,6 +28,7 @@ var delimiterFixtures = []delimiterFixture{
 	{":", token.TokenType(':')},
 	{".", token.TokenType('.')},
 	{"/", token.TokenType('/')},
+	{"=", token.TokenType('=')},
 }
 
 func assertLexerError(t *testing.T, stringSrc string, expected string) {
@@ -18,6 +18,7 @@ var controlCharacterTable = [128]bool{
 	':': true,
 	'.': true,
 	',': true,
+	'=': true,
 }
 
 // Whitespace character table.
@@ -45,6 +46,7 @@ var delimiterCharacterTable = [128]bool{
 	'.':  true,
 	'/':  true,
 	',':  true,
+	'=':  true,
 }
 
 // Identifier character table.
@@ -24,38 +24,77 @@ func PrintError(err errors.ParseError) {
 			term.Printf(term.BOLD|term.MAGENTA, "imported from here")
 		} else {
 			term.Printf(term.BOLD|term.RED, "error: ")
-			term.Printf(term.BOLD, err.Description())
+			term.Printf(term.BOLD, "%s", err.Description())
 		}
 
 		fmt.Println()
 
-		// Print the problematic line.
-		line := frame.Source.Line(frame.Start.Line)
-		fmt.Println(utils.ExpandTabs(line, tabWidth))
+		printFrameSource(frame)
 
-		// Print the pointing arrow and curly marker.
-		start := frame.Start.Character
-		end := frame.End.Character
-
-		if frame.End.Line > frame.Start.Line {
-			end = len(line) + 1
-		}
-
-		if start > 1 {
-			fmt.Print(utils.MaskWithWhitespaceExpanded(line[:start-1], tabWidth))
+		// Create an extra empty white line between frames.
+		if i < len(err.Frames())-1 {
+			fmt.Println()
 		}
+	}
 
-		term.Printf(term.GREEN, "^")
+	// Print the notes.
+	for _, note := range err.Notes() {
+		term.Printf(term.BOLD, "%s:%d:%d: ", note.Source.Path(), note.Start.Line, note.Start.Character)
+		term.Printf(term.BOLD|term.CYAN, "note: ")
+		term.Printf(term.BOLD, "%s", note.Description)
+		fmt.Println()
 
-		if end > start {
-			term.Printf(term.GREEN, strings.Repeat("~", end-start))
-		}
+		printFrameSource(note.ParseErrorFrame)
+	}
+}
 
-		fmt.Println()
+// Print every error of an error in a human readable format.
+//
+// Prints at most limit errors unless limit is 0, followed by the total number
+// of errors.
+func PrintErrors(err errors.ParseError, limit int) {
+	errs := errors.ParseErrors(err)
 
-		// Create an extra empty white line between frames.
-		if i < len(err.Frames())-1 {
+	for i, e := range errs {
+		if limit > 0 && i == limit {
+			term.Printf(term.BOLD, "%d more errors not shown", len(errs)-limit)
 			fmt.Println()
+			break
 		}
+
+		PrintError(e)
+	}
+
+	if len(errs) == 1 {
+		fmt.Println("1 error generated.")
+	} else {
+		fmt.Printf("%d errors generated.\n", len(errs))
+	}
+}
+
+// Print the source line of a frame with a marker pointing at the frame.
+func printFrameSource(frame errors.ParseErrorFrame) {
+	// Print the problematic line.
+	line := frame.Source.Line(frame.Start.Line)
+	fmt.Println(utils.ExpandTabs(line, tabWidth))
+
+	// Print the pointing arrow and curly marker.
+	start := frame.Start.Character
+	end := frame.End.Character
+
+	if frame.End.Line > frame.Start.Line {
+		end = len(line) + 1
+	}
+
+	if start > 1 {
+		fmt.Print(utils.MaskWithWhitespaceExpanded(line[:start-1], tabWidth))
 	}
+
+	term.Printf(term.GREEN, "^")
+
+	if end > start {
+		term.Printf(term.GREEN, "%s", strings.Repeat("~", end-start))
+	}
+
+	fmt.Println()
 }
@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"entangle/errors"
+	"entangle/lexer"
+	"entangle/source"
+	"entangle/token"
+	"os"
+	"path/filepath"
+)
+
+// Import resolver.
+//
+// Shared between the source parsers of a root file and all of its imports to
+// make sure that every file is only parsed once and that import cycles are
+// detected.
+type importResolver struct {
+	// Options.
+	options *Options
+
+	// Parsed interfaces by absolute path.
+	interfaces map[string]*declarations.Interface
+
+	// Absolute paths of interfaces by definition name.
+	definitionPaths map[string]string
+
+	// Stack of absolute paths of the files currently being parsed.
+	stack []string
+}
+
+// New import resolver.
+func newImportResolver(options *Options) *importResolver {
+	return &importResolver{
+		options:         options,
+		interfaces:      map[string]*declarations.Interface{},
+		definitionPaths: map[string]string{},
+		stack:           []string{},
+	}
+}
+
+// Parse a source file.
+//
+// The provided error frames describe the chain of imports leading to the
+// source file.
+func (r *importResolver) parse(src *source.Source, errorFrames []errors.ParseErrorFrame) (interfaceDeclaration *declarations.Interface, err error) {
+	interfaceDeclaration = declarations.NewInterface()
+
+	absPath, absErr := filepath.Abs(src.Path())
+	if absErr != nil {
+		absPath = src.Path()
+	}
+
+	r.stack = append(r.stack, absPath)
+	defer func() {
+		r.stack = r.stack[:len(r.stack)-1]
+	}()
+
+	// Create a source parser.
+	p := &sourceParser{
+		lex:                lexer.NewLexer(src, errorFrames),
+		src:                src,
+		path:               absPath,
+		documentationLines: []token.Token{},
+		errorFrames:        errorFrames,
+		decl:               interfaceDeclaration,
+		resolver:           r,
+		declarationSpans:   map[interface{}]sourceSpan{},
+	}
+
+	if err = p.parse(); err != nil {
+		return
+	}
+
+	r.interfaces[absPath] = interfaceDeclaration
+	return
+}
+
+// Register the definition name of a file.
+//
+// Returns the path of the file already declaring the definition name if it
+// is in use by another file.
+func (r *importResolver) registerDefinition(name, absPath string) (conflictingPath string, conflict bool) {
+	if path, taken := r.definitionPaths[name]; taken && path != absPath {
+		return path, true
+	}
+
+	r.definitionPaths[name] = absPath
+	return "", false
+}
+
+// Resolve an import path.
+//
+// Relative import paths are resolved relative to the directory of the
+// importing file first and then against each of the search paths. Returns
+// both the resolved path for presentation and the absolute path.
+func (r *importResolver) resolve(path, importingPath string) (resolvedPath, absPath string, found bool) {
+	candidates := []string{}
+
+	if filepath.IsAbs(path) {
+		candidates = append(candidates, path)
+	} else {
+		candidates = append(candidates, filepath.Join(filepath.Dir(importingPath), path))
+
+		for _, searchPath := range r.options.ImportPaths {
+			candidates = append(candidates, filepath.Join(searchPath, path))
+		}
+	}
+
+	for _, candidate := range candidates {
+		stat, err := os.Stat(candidate)
+		if err != nil || stat.IsDir() {
+			continue
+		}
+
+		if absPath, err = filepath.Abs(candidate); err != nil {
+			continue
+		}
+
+		return candidate, absPath, true
+	}
+
+	return "", "", false
+}
+
+// Determine if an absolute path is currently being parsed.
+//
+// If so, the returned cycle describes the chain of imports from the first
+// occurence of the path.
+func (r *importResolver) cycle(absPath string) (cycle []string, found bool) {
+	for i, path := range r.stack {
+		if path == absPath {
+			cycle = make([]string, 0, len(r.stack)-i+1)
+			cycle = append(cycle, r.stack[i:]...)
+			cycle = append(cycle, absPath)
+			return cycle, true
+		}
+	}
+
+	return nil, false
+}
+
+// Path relative to the directory of a file if possible.
+func relativePath(filePath, path string) string {
+	if relPath, err := filepath.Rel(filepath.Dir(filePath), path); err == nil {
+		return relPath
+	}
+
+	return path
+}
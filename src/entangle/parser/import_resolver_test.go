@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"entangle/errors"
+	"entangle/source"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Write definition files to a temporary directory.
+func writeDefinitionFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "entangle-parser-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+
+		if err = os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatalf("failed to create directory for %s: %v", name, err)
+		}
+
+		if err = ioutil.WriteFile(path, []byte(content), 0666); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	return dir
+}
+
+// Parse a definition file with options.
+func parseDefinitionFile(t *testing.T, path string, options *Options) (*declarations.Interface, error) {
+	src, err := source.FromFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+
+	return ParseWithOptions(src, options)
+}
+
+func TestImports(t *testing.T) {
+	dir := writeDefinitionFiles(t, map[string]string{
+		"main.etg": `definition main
+import "shared/common.etg"
+import ids "ids.etg"
+
+struct User {
+    1: ID ids.Identifier
+    2: Order *common.Order
+}
+`,
+		"ids.etg": `definition identifiers
+import "shared/common.etg"
+
+struct Identifier {
+    1: Value uint64
+    2: Order common.Order
+}
+`,
+		"shared/common.etg": `definition common
+
+enum Order {
+    1: Ascending
+}
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	decl, err := parseDefinitionFile(t, filepath.Join(dir, "main.etg"), &Options{})
+	if err != nil {
+		t.Fatalf("unexpected error parsing imports: %v", err)
+	}
+
+	if len(decl.Imports) != 2 || decl.Imports["common"] == nil || decl.Imports["ids"] == nil {
+		t.Fatalf("expected imports 'common' and 'ids', got %v", decl.Imports)
+	}
+
+	if decl.Imports["common"].Interface != decl.Imports["ids"].Interface.Imports["common"].Interface {
+		t.Errorf("expected a file imported twice to be parsed once")
+	}
+
+	user := decl.Structs["User"]
+	idType, ok := user.Fields[0].Type.(*declarations.StructType)
+	if !ok || idType.Import() != decl.Imports["ids"] || idType.Struct().Name != "Identifier" {
+		t.Errorf("expected ID to reference ids.Identifier")
+	}
+
+	orderType, ok := user.Fields[1].Type.(*declarations.EnumType)
+	if !ok || orderType.Import() != decl.Imports["common"] || !orderType.Nilable() {
+		t.Errorf("expected Order to reference nilable common.Order")
+	}
+}
+
+func TestImportSearchPaths(t *testing.T) {
+	dir := writeDefinitionFiles(t, map[string]string{
+		"src/main.etg": `definition main
+import "common.etg"
+`,
+		"lib/common.etg": `definition common
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	if _, err := parseDefinitionFile(t, filepath.Join(dir, "src", "main.etg"), &Options{}); err == nil {
+		t.Errorf("expected import outside of search paths to fail")
+	}
+
+	if _, err := parseDefinitionFile(t, filepath.Join(dir, "src", "main.etg"), &Options{
+		ImportPaths: []string{filepath.Join(dir, "lib")},
+	}); err != nil {
+		t.Errorf("unexpected error resolving import against search paths: %v", err)
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	dir := writeDefinitionFiles(t, map[string]string{
+		"a.etg": `definition a
+import "b.etg"
+`,
+		"b.etg": `definition b
+import "a.etg"
+`,
+		"c.etg": `definition c
+import "d.etg"
+`,
+		"d.etg": `definition d
+
+struct X {
+    1: Y Unknown
+}
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	for _, testCase := range []struct {
+		Path        string
+		Description string
+		Frames      int
+	}{
+		{"a.etg", "import cycle: a.etg -> b.etg -> a.etg", 2},
+		{"c.etg", "unknown type 'Unknown'", 2},
+	} {
+		_, err := parseDefinitionFile(t, filepath.Join(dir, testCase.Path), &Options{})
+
+		parseErr, ok := err.(errors.ParseError)
+		if !ok {
+			t.Errorf("expected parse error for %s, got %v", testCase.Path, err)
+			continue
+		}
+
+		if parseErr.Description() != testCase.Description {
+			t.Errorf("expected error '%s' for %s, got '%s'", testCase.Description, testCase.Path, parseErr.Description())
+		}
+
+		if len(parseErr.Frames()) != testCase.Frames {
+			t.Errorf("expected %d error frames for %s, got %d", testCase.Frames, testCase.Path, len(parseErr.Frames()))
+		}
+	}
+}
@@ -4,26 +4,24 @@ package parser
 import (
 	"entangle/declarations"
 	"entangle/errors"
-	"entangle/lexer"
 	"entangle/source"
-	"entangle/token"
 )
 
+// Parser options.
+type Options struct {
+	// Import search paths.
+	//
+	// Imports are resolved relative to the importing file first and then
+	// against each of the search paths in order.
+	ImportPaths []string
+}
+
 // Parse an Entangle IDL file to an interface declaration.
 func Parse(src *source.Source) (interfaceDeclaration *declarations.Interface, err error) {
-	interfaceDeclaration = declarations.NewInterface()
-
-	// Create a source parser.
-	errorFrames := []errors.ParseErrorFrame{}
-
-	p := &sourceParser{
-		lex:                lexer.NewLexer(src, errorFrames),
-		src:                src,
-		documentationLines: []token.Token{},
-		errorFrames:        errorFrames,
-		decl:               interfaceDeclaration,
-	}
+	return ParseWithOptions(src, &Options{})
+}
 
-	err = p.parse()
-	return
+// Parse an Entangle IDL file to an interface declaration with options.
+func ParseWithOptions(src *source.Source, options *Options) (interfaceDeclaration *declarations.Interface, err error) {
+	return newImportResolver(options).parse(src, []errors.ParseErrorFrame{})
 }
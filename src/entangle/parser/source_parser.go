@@ -13,20 +13,73 @@ import (
 // Internal context manager for parsing one source file.
 type sourceParser struct {
 	src                *source.Source
+	path               string
 	lex                *lexer.Lexer
 	documentationLines []token.Token
 	errorFrames        []errors.ParseErrorFrame
 	prev               token.Token
 	tok                token.Token
 	decl               *declarations.Interface
+	resolver           *importResolver
+
+	// Name tokens of struct, union and type alias declarations in the order
+	// of the source.
+	typeDeclarationTokens []token.Token
+
+	// References to types not yet declared at the time of parsing.
+	unresolvedTypes []*unresolvedType
+
+	// Annotations preceding the declaration being parsed.
+	annotations declarations.Annotations
+
+	// Checks deferred until all types are resolved.
+	deferredChecks []func() error
+
+	// Errors recovered from, in the order of the source.
+	parseErrors []errors.ParseError
+
+	// Source spans of reservations, fields and arguments.
+	declarationSpans map[interface{}]sourceSpan
+
+	// Token following the current token, if peeked at.
+	peekTok token.Token
+	peeked  bool
 }
 
 func (p *sourceParser) next() (err error) {
 	p.prev = p.tok
-	p.tok, err = p.lex.Lex()
+
+	if p.peeked {
+		p.tok, p.peeked = p.peekTok, false
+		return
+	}
+
+	if p.tok, err = p.lex.Lex(); err != nil {
+		// Skip the rest of the line, leaving the new line ending it as the
+		// current token, to allow recovering from the error.
+		p.lex.SkipLine()
+		p.tok, _ = p.lex.Lex()
+	}
+
 	return
 }
 
+// Peek at the token following the current token.
+func (p *sourceParser) peek() (tok token.Token, err error) {
+	if !p.peeked {
+		p.peekTok, err = p.lex.Lex()
+		p.peeked = true
+
+		if err != nil {
+			p.lex.SkipLine()
+			p.peekTok, _ = p.lex.Lex()
+			return
+		}
+	}
+
+	return p.peekTok, nil
+}
+
 func (p *sourceParser) parse() (err error) {
 	// Start out by reading the definition name.
 	for {
@@ -58,10 +111,12 @@ func (p *sourceParser) parse() (err error) {
 	}
 
 	// Read through till the end.
+	//
+	// Declaration parsers leave the source positioned at the token following
+	// the declaration, so only new lines and documentation lines are skipped
+	// here.
 	for p.tok.Type != token.EndOfFile {
-		if err = p.next(); err != nil {
-			return
-		}
+		start := p.tok.Start
 
 		switch p.tok.Type {
 		case token.NewLine:
@@ -71,12 +126,27 @@ func (p *sourceParser) parse() (err error) {
 				p.documentationLines = []token.Token{}
 			}
 
+			err = p.next()
+
 		case token.DocumentationLine:
 			// Store the documentation line.
 			p.documentationLines = append(p.documentationLines, p.tok)
 
+			err = p.next()
+
+		case token.TokenType('@'):
+			err = p.parseAnnotations()
+
 		case token.Import:
-			err = p.parseImport()
+			if err = p.checkNotAnnotated(); err == nil {
+				err = p.parseImport()
+			}
+
+		case token.Const:
+			err = p.parseConstant()
+
+		case token.Typedef:
+			err = p.parseTypedef()
 
 		case token.Struct:
 			err = p.parseStruct()
@@ -85,7 +155,17 @@ func (p *sourceParser) parse() (err error) {
 			err = p.parseException()
 
 		case token.Enum:
-			err = p.parseEnum()
+			err = p.parseEnum(false)
+
+		case token.Identifier:
+			// Flags and unions are declared by contextual keywords.
+			if p.atKeyword(flagsKeyword) {
+				err = p.parseEnum(true)
+			} else if p.atKeyword(unionKeyword) {
+				err = p.parseUnion()
+			} else {
+				err = p.parseErrorHere("unexpected token")
+			}
 
 		case token.Service:
 			err = p.parseService()
@@ -95,9 +175,24 @@ func (p *sourceParser) parse() (err error) {
 		}
 
 		if err != nil {
+			if err = p.recoverFromDeclarationError(err, start); err != nil {
+				return
+			}
+		}
+	}
+
+	if err = p.checkNotAnnotated(); err != nil {
+		if err = p.recordError(err); err != nil {
 			return
 		}
 	}
 
-	return
+	// References cannot be reliably resolved with declarations missing due
+	// to errors.
+	if len(p.parseErrors) > 0 {
+		return errors.ParseErrorList(p.parseErrors)
+	}
+
+	// Resolve references to types declared after being referenced.
+	return p.resolve()
 }
@@ -0,0 +1,367 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"entangle/token"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Parse annotations.
+//
+// Invoked with the '@' of the first annotation as the current token.
+// Annotations may be interleaved with new lines and documentation lines, which
+// are stored as documentation. Returns with the first other token as the
+// current token, which is expected to start the annotated declaration.
+func (p *sourceParser) parseAnnotations() (err error) {
+	for {
+		switch p.tok.Type {
+		case token.TokenType('@'):
+			var annotation *declarations.Annotation
+
+			if annotation, err = p.parseAnnotation(); err != nil {
+				return
+			}
+
+			if p.annotations.Has(annotation.Name) {
+				span := p.declarationSpans[annotation]
+				return p.parseError(fmt.Sprintf("annotation '@%s' already declared", annotation.Name), span.start, span.end)
+			} else if err = p.validateAnnotation(annotation); err != nil {
+				return
+			}
+
+			p.annotations = append(p.annotations, annotation)
+
+		case token.NewLine:
+			if err = p.next(); err != nil {
+				return
+			}
+
+		case token.DocumentationLine:
+			p.documentationLines = append(p.documentationLines, p.tok)
+
+			if err = p.next(); err != nil {
+				return
+			}
+
+		default:
+			return
+		}
+	}
+}
+
+// Parse an annotation.
+//
+// Invoked with the '@' as the current token. Returns with the token following
+// the annotation as the current token. The span of the '@' and the name is
+// recorded as the span of the annotation.
+func (p *sourceParser) parseAnnotation() (annotation *declarations.Annotation, err error) {
+	contextDesc := "annotation"
+	span := tokenSpan(&p.tok)
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// Parse the dot separated name.
+	nameParts := make([]string, 0, 2)
+
+	for {
+		switch p.tok.Type {
+		case token.Identifier:
+			nameParts = append(nameParts, p.tok.StringValue)
+			span.end = p.tok.End
+
+		case token.NewLine:
+			return nil, p.parseErrorHeref("unexpected end of line in %s", contextDesc)
+
+		case token.EndOfFile:
+			return nil, p.parseErrorHeref("unexpected end of file in %s", contextDesc)
+
+		default:
+			return nil, p.parseErrorHere("expected annotation name")
+		}
+
+		if err = p.next(); err != nil {
+			return
+		}
+
+		if p.tok.Type != token.TokenType('.') {
+			break
+		}
+
+		if err = p.next(); err != nil {
+			return
+		}
+	}
+
+	annotation = &declarations.Annotation{
+		Name:      strings.Join(nameParts, "."),
+		Arguments: []*declarations.AnnotationArgument{},
+	}
+	p.declarationSpans[annotation] = span
+
+	// The name is optionally followed by arguments.
+	if p.tok.Type != token.TokenType('(') {
+		return
+	}
+
+	if err = p.nextAndSkipNewLines(); err != nil {
+		return
+	}
+
+	for p.tok.Type != token.TokenType(')') {
+		arg := &declarations.AnnotationArgument{}
+
+		// Arguments are optionally prefixed by a key and an equals sign.
+		if p.tok.Type == token.Identifier && p.tok.StringValue != "true" && p.tok.StringValue != "false" {
+			arg.Key = p.tok.StringValue
+
+			if annotation.Argument(arg.Key) != nil {
+				return nil, p.parseErrorHeref("argument '%s' already declared in %s", arg.Key, contextDesc)
+			}
+
+			if err = p.next(); err != nil {
+				return
+			}
+
+			if err = p.expectRune('=', contextDesc); err != nil {
+				return
+			}
+		}
+
+		if arg.Value, err = p.parseAnnotationValue(contextDesc); err != nil {
+			return
+		}
+
+		annotation.Arguments = append(annotation.Arguments, arg)
+
+		if err = p.next(); err != nil {
+			return
+		}
+
+		// If this is not the last argument, we should expect a comma here.
+		if p.tok.Type != token.TokenType(')') {
+			if err = p.expectRune(',', contextDesc); err != nil {
+				return
+			}
+
+			if err = p.skipNewLines(); err != nil {
+				return
+			}
+		}
+	}
+
+	return annotation, p.next()
+}
+
+// Validate the arguments of an annotation known to the parser.
+//
+// Unknown annotations are accepted as is.
+func (p *sourceParser) validateAnnotation(annotation *declarations.Annotation) error {
+	span := p.declarationSpans[annotation]
+	invalid := func(format string) error {
+		return p.parseError(fmt.Sprintf(format, annotation.Name), span.start, span.end)
+	}
+
+	switch annotation.Name {
+	case declarations.DeprecatedAnnotation:
+		if len(annotation.Arguments) == 0 {
+			break
+		}
+
+		arg := annotation.Arguments[0]
+		if _, isString := arg.Value.(string); len(annotation.Arguments) > 1 || !isString || (arg.Key != "" && arg.Key != "reason") {
+			return invalid("annotation '@%s' takes an optional reason string as its only argument")
+		}
+
+	case declarations.RangeAnnotation, declarations.LengthAnnotation:
+		desc := "annotation '@%s' takes a minimum 'min' and a maximum 'max' number, of which at least one is required"
+		if annotation.Name == declarations.LengthAnnotation {
+			desc = "annotation '@%s' takes a minimum 'min' and a maximum 'max' length, of which at least one is required"
+		}
+
+		if len(annotation.Arguments) == 0 {
+			return invalid(desc)
+		}
+
+		for _, arg := range annotation.Arguments {
+			if arg.Key != "min" && arg.Key != "max" {
+				return invalid(desc)
+			}
+
+			switch arg.Value.(type) {
+			case uint64:
+			case int64, float64:
+				if annotation.Name == declarations.LengthAnnotation {
+					return invalid(desc)
+				}
+
+			default:
+				return invalid(desc)
+			}
+		}
+
+		min, max := annotation.Argument("min"), annotation.Argument("max")
+		if min != nil && max != nil && compareNumbers(min.Value, max.Value) > 0 {
+			return invalid("maximum of annotation '@%s' precedes the minimum")
+		}
+
+	case declarations.PatternAnnotation:
+		if len(annotation.Arguments) != 1 || annotation.Arguments[0].Key != "" {
+			return invalid("annotation '@%s' takes a regular expression string as its only argument")
+		}
+
+		pattern, isString := annotation.Arguments[0].Value.(string)
+		if !isString {
+			return invalid("annotation '@%s' takes a regular expression string as its only argument")
+		}
+
+		if _, err := regexp.Compile(pattern); err != nil {
+			return p.parseError(fmt.Sprintf("invalid regular expression in annotation '@%s': %v", annotation.Name, err), span.start, span.end)
+		}
+
+	case declarations.TimeoutAnnotation:
+		if len(annotation.Arguments) != 1 || annotation.Arguments[0].Key != "" {
+			return invalid("annotation '@%s' takes a positive duration as its only argument")
+		}
+
+		if timeout, isDuration := annotation.Arguments[0].Value.(time.Duration); !isDuration || timeout <= 0 {
+			return invalid("annotation '@%s' takes a positive duration as its only argument")
+		}
+
+	case declarations.NonEmptyAnnotation, declarations.OpenAnnotation, declarations.FallbackAnnotation, declarations.PatchAnnotation, declarations.PolymorphicAnnotation, declarations.IdempotentAnnotation:
+		if len(annotation.Arguments) > 0 {
+			return invalid("annotation '@%s' takes no arguments")
+		}
+	}
+
+	return nil
+}
+
+// Parse an annotation argument value from the current token.
+func (p *sourceParser) parseAnnotationValue(contextDesc string) (value interface{}, err error) {
+	switch p.tok.Type {
+	case token.Literal:
+		return p.tok.StringValue, nil
+
+	case token.IntConstant:
+		return p.tok.IntValue, nil
+
+	case token.UintConstant:
+		return p.tok.UintValue, nil
+
+	case token.FloatConstant:
+		return p.tok.FloatValue, nil
+
+	case token.DurationConstant:
+		return p.tok.DurationValue, nil
+
+	case token.Identifier:
+		switch p.tok.StringValue {
+		case "true":
+			return true, nil
+
+		case "false":
+			return false, nil
+		}
+
+	case token.NewLine:
+		return nil, p.parseErrorHeref("unexpected end of line in %s", contextDesc)
+
+	case token.EndOfFile:
+		return nil, p.parseErrorHeref("unexpected end of file in %s", contextDesc)
+	}
+
+	return nil, p.parseErrorHere("expected bool, number, duration or string literal as annotation value")
+}
+
+// Kind of an annotated declaration.
+type annotationTarget int
+
+const (
+	constantTarget annotationTarget = iota
+	typedefTarget
+	structTarget
+	exceptionTarget
+	unionTarget
+	enumTarget
+	flagsTarget
+	enumValueTarget
+	fieldTarget
+	serviceTarget
+	functionTarget
+	argumentTarget
+)
+
+// Declarations on which an annotation known to the parser takes effect.
+type knownAnnotationTargets struct {
+	// Description of the declarations, e.g. "functions".
+	desc string
+
+	// Kinds of the declarations.
+	targets []annotationTarget
+}
+
+// Declarations on which annotations known to the parser take effect.
+//
+// Known annotations not in the map, like @deprecated, take effect on any
+// declaration.
+var annotationTargets = map[string]knownAnnotationTargets{
+	declarations.RangeAnnotation:       {"fields and arguments", []annotationTarget{fieldTarget, argumentTarget}},
+	declarations.LengthAnnotation:      {"fields and arguments", []annotationTarget{fieldTarget, argumentTarget}},
+	declarations.PatternAnnotation:     {"fields and arguments", []annotationTarget{fieldTarget, argumentTarget}},
+	declarations.NonEmptyAnnotation:    {"fields and arguments", []annotationTarget{fieldTarget, argumentTarget}},
+	declarations.OpenAnnotation:        {"enumerations and flags", []annotationTarget{enumTarget, flagsTarget}},
+	declarations.FallbackAnnotation:    {"enumeration values", []annotationTarget{enumValueTarget}},
+	declarations.PatchAnnotation:       {"structs", []annotationTarget{structTarget}},
+	declarations.PolymorphicAnnotation: {"structs", []annotationTarget{structTarget}},
+	declarations.TimeoutAnnotation:     {"functions", []annotationTarget{functionTarget}},
+	declarations.IdempotentAnnotation:  {"functions", []annotationTarget{functionTarget}},
+}
+
+// Get the annotations of the declaration being parsed.
+//
+// Reading the annotations clears them, like documentation paragraphs. Known
+// annotations are rejected unless they take effect on the kind of the
+// declaration.
+func (p *sourceParser) declarationAnnotations(target annotationTarget) (declarations.Annotations, error) {
+	annotations := p.annotations
+	p.annotations = nil
+
+	for _, annotation := range annotations {
+		known, found := annotationTargets[annotation.Name]
+		if !found {
+			continue
+		}
+
+		allowed := false
+		for _, t := range known.targets {
+			if t == target {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			span := p.declarationSpans[annotation]
+			return nil, p.parseError(fmt.Sprintf("annotation '@%s' is only allowed on %s", annotation.Name, known.desc), span.start, span.end)
+		}
+	}
+
+	return annotations, nil
+}
+
+// Make sure that no annotations precede the current token.
+//
+// Used where the current token does not start a declaration which could be
+// annotated.
+func (p *sourceParser) checkNotAnnotated() error {
+	if len(p.annotations) > 0 {
+		return p.parseErrorHere("expected annotated declaration following annotations")
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestAnnotations(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"struct", "@table(name = \"users\", shards = 4)\n@audited\nstruct User {\n    1: ID int64\n}\n", ""},
+		{"field", "struct User {\n    @go.name(\"ID\")\n    1: ID int64\n}\n", ""},
+		{"enum value", "enum Level {\n    @hidden\n    1: Low\n}\n", ""},
+		{"function and arguments", "@internal\nservice Users {\n    @cached(ttl = 30)\n    Get(@positive 1: id int64,\n        @sensitive\n        2: token string) string\n}\n", ""},
+		{"multiple lines of arguments", "@table(\n    name = \"users\",\n    shards = 4)\nstruct User {\n    1: ID int64\n}\n", ""},
+		{"literal arguments", "@values(true, -1, 0.5, \"text\")\nstruct User {\n    1: ID int64\n}\n", ""},
+		{"duplicate annotation", "@audited @audited\nstruct User {\n    1: ID int64\n}\n", "annotation '@audited' already declared"},
+		{"duplicate argument", "@table(name = \"users\", name = \"people\")\nstruct User {\n    1: ID int64\n}\n", "argument 'name' already declared in annotation"},
+		{"missing name", "@ 1\nstruct User {\n    1: ID int64\n}\n", "expected annotation name"},
+		{"missing argument value", "@table(name = )\nstruct User {\n    1: ID int64\n}\n", "expected bool, number, duration or string literal as annotation value"},
+		{"missing comma", "@table(name = \"users\" shards = 4)\nstruct User {\n    1: ID int64\n}\n", "expected ',' in annotation"},
+		{"unannotated", "struct User {\n    1: ID int64\n    @hidden\n}\n", "expected annotated declaration following annotations"},
+	})
+}
+
+func TestAnnotationArguments(t *testing.T) {
+	decl := mustParseTestSource(t, `@table(name = "users", shards = 4)
+@go.audited
+struct User {
+    @go.name("ID")
+    1: ID int64
+}
+`)
+
+	annotations := decl.Structs["User"].Annotations
+	if len(annotations) != 2 || !annotations.Has("go.audited") {
+		t.Fatalf("expected annotations table and go.audited of User, got %d annotations", len(annotations))
+	}
+
+	table := annotations.Annotation("table")
+	if name := table.Argument("name"); name == nil || name.Value != "users" {
+		t.Errorf("expected argument name \"users\" of table, got %v", name)
+	}
+
+	if shards := table.Argument("shards"); shards == nil || shards.Value != uint64(4) {
+		t.Errorf("expected argument shards 4 of table, got %v", shards)
+	}
+
+	goName := decl.Structs["User"].Fields[0].Annotations.Annotation("go.name")
+	if goName == nil || len(goName.PositionalArguments()) != 1 || goName.PositionalArguments()[0].Value != "ID" {
+		t.Errorf("expected positional argument \"ID\" of go.name, got %v", goName)
+	}
+}
+
+func TestAnnotationTargets(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"deprecated anywhere", "@deprecated\nconst Max uint32 = 1\n\n@deprecated\ntypedef ID uint64\n\n@deprecated\nenum Level {\n    @deprecated\n    1: Low\n}\n\n@deprecated\nexception Failed\n\n@deprecated\nstruct User {\n    @deprecated\n    1: ID int64\n}\n\n@deprecated\nservice Users {\n    @deprecated\n    Get(@deprecated 1: id int64) User\n}\n", ""},
+		{"unknown anywhere", "@hidden\nconst Max uint32 = 1\n\n@hidden\ntypedef ID uint64\n\n@hidden\nunion Value {\n    @hidden\n    1: Text string\n}\n", ""},
+		{"function annotations", "service Users {\n    @timeout(5s)\n    @idempotent\n    Get(1: id int64) string\n}\n", ""},
+		{"timeout on struct", "@timeout(5s)\nstruct User {\n    1: ID int64\n}\n", "annotation '@timeout' is only allowed on functions"},
+		{"idempotent on service", "@idempotent\nservice Users {\n    Get(1: id int64) string\n}\n", "annotation '@idempotent' is only allowed on functions"},
+		{"idempotent on argument", "service Users {\n    Get(@idempotent 1: id int64) string\n}\n", "annotation '@idempotent' is only allowed on functions"},
+		{"patch on field", "struct User {\n    @patch\n    1: ID int64\n}\n", "annotation '@patch' is only allowed on structs"},
+		{"polymorphic on exception", "@polymorphic\nexception Failed\n", "annotation '@polymorphic' is only allowed on structs"},
+		{"open on struct", "@open\nstruct User {\n    1: ID int64\n}\n", "annotation '@open' is only allowed on enumerations and flags"},
+		{"open on enum value", "enum Level {\n    @open\n    1: Low\n}\n", "annotation '@open' is only allowed on enumerations and flags"},
+		{"fallback on enum", "@fallback\nenum Level {\n    1: Low\n}\n", "annotation '@fallback' is only allowed on enumeration values"},
+		{"range on struct", "@range(min = 1)\nstruct User {\n    1: ID int64\n}\n", "annotation '@range' is only allowed on fields and arguments"},
+		{"length on typedef", "@length(max = 10)\ntypedef Name string\n", "annotation '@length' is only allowed on fields and arguments"},
+		{"pattern on constant", "@pattern(\"^a\")\nconst Name string = \"a\"\n", "annotation '@pattern' is only allowed on fields and arguments"},
+		{"nonempty on function", "service Users {\n    @nonempty\n    Get(1: id int64) string\n}\n", "annotation '@nonempty' is only allowed on fields and arguments"},
+	})
+}
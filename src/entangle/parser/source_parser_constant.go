@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"entangle/token"
+)
+
+// Parse a constant declaration.
+func (p *sourceParser) parseConstant() (err error) {
+	contextDesc := "constant declaration"
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// Parse the name.
+	var name string
+
+	switch p.tok.Type {
+	case token.NewLine:
+		return p.parseErrorHeref("unexpected end of line in %s", contextDesc)
+
+	case token.EndOfFile:
+		return p.parseErrorHeref("unexpected end of file in %s", contextDesc)
+
+	case token.Identifier:
+		if err = p.validateConstantName(&p.tok); err != nil {
+			return
+		}
+
+		name = p.tok.StringValue
+
+		if p.decl.NameInUse(name) {
+			return p.parseErrorHeref("constant name '%s' would override previous declaration", name)
+		}
+
+	default:
+		return p.parseErrorHere("expected constant name")
+	}
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// Then a type.
+	var constantType declarations.Type
+	if constantType, err = p.parseType(contextDesc); err != nil {
+		return
+	}
+
+	if constantType.Nilable() {
+		return p.parseErrorHere("constants cannot be nilable")
+	}
+
+	typeTok := p.tok
+
+	if err = p.whenResolved(func() (err error) {
+		var resolvedType declarations.Type
+		if resolvedType, err = p.resolveType(constantType); err != nil {
+			return
+		}
+
+		if !valueTypeSupported(resolvedType) {
+			return p.parseErrorForToken("constants must be of a bool, string, numeric or enumeration type", &typeTok)
+		}
+
+		return
+	}, constantType); err != nil {
+		return
+	}
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// The type should be followed by an equals sign ('=') and the value.
+	if err = p.expectRune('=', contextDesc); err != nil {
+		return
+	}
+
+	decl := declarations.NewConstant(name, p.documentationParagraphs(), constantType, nil)
+	if decl.Annotations, err = p.declarationAnnotations(constantTarget); err != nil {
+		return
+	}
+
+	if err = p.parseValueInto(constantType, contextDesc, &decl.Value); err != nil {
+		return
+	}
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// The value should be followed by an end of line or file.
+	switch p.tok.Type {
+	case token.NewLine, token.EndOfFile:
+		break
+
+	default:
+		return p.parseErrorHere("expected new line following constant declaration")
+	}
+
+	// Add the constant declaration.
+	p.decl.AddConstant(decl)
+
+	return p.next()
+}
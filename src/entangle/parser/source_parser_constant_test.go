@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"testing"
+)
+
+func TestConstants(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"unsigned", "const MaxPageSize uint32 = 500\n", ""},
+		{"signed", "const MinOffset int8 = -128\n", ""},
+		{"string", "const Greeting string = \"hello\"\n", ""},
+		{"float", "const Ratio float64 = 0.5\n", ""},
+		{"bool", "const Enabled bool = true\n", ""},
+		{"enum", "enum Role {\n    1: Member\n}\n\nconst DefaultRole Role = Member\n", ""},
+		{"typedef", "typedef Limit uint32\n\nconst MaxLimit Limit = 10\n", ""},
+		{"invalid name", "const maxPageSize uint32 = 500\n", "'maxPageSize' is not a valid constant name. Constant names must be upper camel case"},
+		{"duplicate name", "const Max uint32 = 1\nconst Max uint32 = 2\n", "constant name 'Max' would override previous declaration"},
+		{"nilable", "const Max *uint32 = 1\n", "constants cannot be nilable"},
+		{"missing value", "const Max uint32\n", "unexpected new line in constant declaration, expected '='"},
+		{"out of range", "const Max uint8 = 256\n", "value out of range for uint8"},
+		{"negative unsigned", "const Max uint8 = -1\n", "value out of range for uint8"},
+		{"string as number", "const Max uint32 = \"1\"\n", "expected unsigned integer as uint32 value"},
+		{"number as string", "const Greeting string = 1\n", "expected string literal as string value"},
+		{"unknown enum value", "enum Role {\n    1: Member\n}\n\nconst DefaultRole Role = Owner\n", "unknown value 'Owner' of enumeration 'Role'"},
+		{"struct", "struct Page {\n    1: Size uint32\n}\n\nconst DefaultPage Page = 1\n", "constants must be of a bool, string, numeric or enumeration type"},
+		{"trailing token", "const Max uint32 = 1 2\n", "expected new line following constant declaration"},
+	})
+}
+
+func TestConstantValues(t *testing.T) {
+	decl := mustParseTestSource(t, `enum Role {
+    1: Member
+    2: Owner
+}
+
+const MaxPageSize uint32 = 500
+const MinOffset int8 = -128
+const Greeting string = "hello"
+const Ratio float32 = 3
+const DefaultRole Role = Owner
+`)
+
+	for name, expected := range map[string]interface{}{
+		"MaxPageSize": uint64(500),
+		"MinOffset":   int64(-128),
+		"Greeting":    "hello",
+		"Ratio":       float64(3),
+	} {
+		if value := decl.Constants[name].Value; value != expected {
+			t.Errorf("expected value %#v of %s, got %#v", expected, name, value)
+		}
+	}
+
+	if value, ok := decl.Constants["DefaultRole"].Value.(declarations.EnumValue); !ok || value.Name != "Owner" || value.Value != 2 {
+		t.Errorf("expected value Owner of DefaultRole, got %#v", decl.Constants["DefaultRole"].Value)
+	}
+}
@@ -0,0 +1,199 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// Compare two numbers.
+//
+// The dynamic type of the numbers is float64, int64 or uint64. Returns a
+// negative number if a is less than b, zero if they are equal and a positive
+// number if a is greater than b.
+func compareNumbers(a, b interface{}) int {
+	_, aFloat := a.(float64)
+	_, bFloat := b.(float64)
+
+	if aFloat || bFloat {
+		return compareFloats(numberAsFloat(a), numberAsFloat(b))
+	}
+
+	aInt, aSigned := a.(int64)
+	bInt, bSigned := b.(int64)
+	aNegative := aSigned && aInt < 0
+	bNegative := bSigned && bInt < 0
+
+	switch {
+	case aNegative && bNegative && aInt < bInt:
+		return -1
+	case aNegative && bNegative && aInt > bInt:
+		return 1
+	case aNegative && bNegative:
+		return 0
+	case aNegative:
+		return -1
+	case bNegative:
+		return 1
+	}
+
+	aUint, bUint := numberAsUint(a), numberAsUint(b)
+
+	switch {
+	case aUint < bUint:
+		return -1
+	case aUint > bUint:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Compare two floating point numbers.
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Convert a number to a floating point number.
+func numberAsFloat(n interface{}) float64 {
+	switch v := n.(type) {
+	case float64:
+		return v
+	case int64:
+		return float64(v)
+	default:
+		return float64(n.(uint64))
+	}
+}
+
+// Convert a non-negative integer to an unsigned integer.
+func numberAsUint(n interface{}) uint64 {
+	if v, ok := n.(int64); ok {
+		return uint64(v)
+	}
+
+	return n.(uint64)
+}
+
+// Determine if a numeric bound can be represented by a type.
+func boundFitsType(bound interface{}, class declarations.TypeClass) bool {
+	switch class {
+	case declarations.Float32Class, declarations.Float64Class:
+		return true
+
+	case declarations.Int8Class, declarations.Int16Class, declarations.Int32Class, declarations.Int64Class:
+		valueRange := signedIntegerRanges[class]
+
+		switch v := bound.(type) {
+		case int64:
+			return v >= valueRange[0]
+		case uint64:
+			return v <= uint64(valueRange[1])
+		}
+
+	case declarations.Uint8Class, declarations.Uint16Class, declarations.Uint32Class, declarations.Uint64Class:
+		if v, ok := bound.(uint64); ok {
+			return v <= unsignedIntegerMaximums[class]
+		}
+	}
+
+	return false
+}
+
+// Check the constraints declared for a value of a type.
+//
+// Invoked once the declaration of the value has been parsed, including the
+// default value if any. The check is deferred until the type is resolved if
+// necessary. Constraints must apply to the type, and the default value must
+// satisfy the constraints.
+func (p *sourceParser) checkConstraints(annotations declarations.Annotations, valueType declarations.Type, defaultValue *interface{}, desc string) error {
+	if !annotations.Constraints().Any() {
+		return nil
+	}
+
+	return p.whenResolved(func() (err error) {
+		var resolvedType declarations.Type
+		if resolvedType, err = p.resolveType(valueType); err != nil {
+			return
+		}
+
+		class := declarations.UnderlyingType(resolvedType).Class()
+		typeName, numeric := valueTypeNames[class]
+		numeric = numeric && class != declarations.BoolClass && class != declarations.StringClass
+
+		for _, a := range annotations {
+			span := p.declarationSpans[a]
+			var applicable bool
+
+			switch a.Name {
+			case declarations.RangeAnnotation:
+				applicable = numeric
+
+			case declarations.LengthAnnotation:
+				applicable = class == declarations.StringClass || class == declarations.BinaryClass || class == declarations.ListClass || class == declarations.SetClass
+
+			case declarations.PatternAnnotation:
+				applicable = class == declarations.StringClass
+
+			case declarations.NonEmptyAnnotation:
+				applicable = class == declarations.MapClass
+
+			default:
+				continue
+			}
+
+			if !applicable {
+				return p.parseError(fmt.Sprintf("annotation '@%s' does not apply to the type of %s", a.Name, desc), span.start, span.end)
+			}
+
+			if a.Name == declarations.RangeAnnotation {
+				for _, arg := range a.Arguments {
+					if !boundFitsType(arg.Value, class) {
+						return p.parseError(fmt.Sprintf("%s bound of annotation '@%s' is not a valid %s value", arg.Key, a.Name, typeName), span.start, span.end)
+					}
+				}
+			}
+
+			if *defaultValue != nil && !satisfiesConstraints(*defaultValue, declarations.Annotations{a}.Constraints()) {
+				return p.parseError(fmt.Sprintf("default value of %s does not satisfy annotation '@%s'", desc, a.Name), span.start, span.end)
+			}
+		}
+
+		return
+	}, valueType)
+}
+
+// Determine if a declared value satisfies constraints.
+//
+// The dynamic type of the value is described by declarations.Constant.
+func satisfiesConstraints(value interface{}, constraints declarations.Constraints) bool {
+	switch v := value.(type) {
+	case string:
+		length := uint64(utf8.RuneCountInString(v))
+
+		if constraints.MinLength != nil && length < constraints.MinLength.(uint64) {
+			return false
+		} else if constraints.MaxLength != nil && length > constraints.MaxLength.(uint64) {
+			return false
+		} else if constraints.Pattern != "" && !regexp.MustCompile(constraints.Pattern).MatchString(v) {
+			return false
+		}
+
+	case float64, int64, uint64:
+		if constraints.Min != nil && compareNumbers(v, constraints.Min) < 0 {
+			return false
+		} else if constraints.Max != nil && compareNumbers(v, constraints.Max) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
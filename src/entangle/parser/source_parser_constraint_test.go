@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestConstraints(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"range", "struct User {\n    @range(min = 1, max = 120)\n    1: Age uint8\n}\n", ""},
+		{"float range", "struct User {\n    @range(min = -10.5, max = 10.5)\n    1: Score *float64\n}\n", ""},
+		{"length", "struct User {\n    @length(min = 1, max = 64)\n    1: Login string\n    @length(max = 8)\n    2: Tags []string\n    @length(max = 1024)\n    3: Avatar binary\n}\n", ""},
+		{"pattern", "typedef Name string\n\nstruct User {\n    @pattern(\"^[a-z]+$\")\n    1: Login Name\n}\n", ""},
+		{"nonempty", "struct User {\n    @nonempty\n    1: Attributes map[string]string\n}\n", ""},
+		{"argument", "service Users {\n    Find(@range(min = 1, max = 10) 1: page uint8 = 1) string\n}\n", ""},
+		{"satisfied default", "struct User {\n    @range(min = 0, max = 120)\n    1: Age int8 = 18\n}\n", ""},
+		{"range without bounds", "struct User {\n    @range\n    1: Age uint8\n}\n", "annotation '@range' takes a minimum 'min' and a maximum 'max' number, of which at least one is required"},
+		{"range with unknown bound", "struct User {\n    @range(low = 1)\n    1: Age uint8\n}\n", "annotation '@range' takes a minimum 'min' and a maximum 'max' number, of which at least one is required"},
+		{"reversed range", "struct User {\n    @range(min = 10, max = 1)\n    1: Age uint8\n}\n", "maximum of annotation '@range' precedes the minimum"},
+		{"negative length", "struct User {\n    @length(min = -1)\n    1: Login string\n}\n", "annotation '@length' takes a minimum 'min' and a maximum 'max' length, of which at least one is required"},
+		{"pattern without expression", "struct User {\n    @pattern\n    1: Login string\n}\n", "annotation '@pattern' takes a regular expression string as its only argument"},
+		{"invalid pattern", "struct User {\n    @pattern(\"[a-z\")\n    1: Login string\n}\n", "invalid regular expression in annotation '@pattern': error parsing regexp: missing closing ]: `[a-z`"},
+		{"nonempty with arguments", "struct User {\n    @nonempty(true)\n    1: Attributes map[string]string\n}\n", "annotation '@nonempty' takes no arguments"},
+		{"range of string", "struct User {\n    @range(min = 1)\n    1: Login string\n}\n", "annotation '@range' does not apply to the type of field 'Login'"},
+		{"length of number", "struct User {\n    @length(max = 1)\n    1: Age uint8\n}\n", "annotation '@length' does not apply to the type of field 'Age'"},
+		{"pattern of list", "struct User {\n    @pattern(\"^a$\")\n    1: Tags []string\n}\n", "annotation '@pattern' does not apply to the type of field 'Tags'"},
+		{"nonempty of list", "struct User {\n    @nonempty\n    1: Tags []string\n}\n", "annotation '@nonempty' does not apply to the type of field 'Tags'"},
+		{"bound out of range", "struct User {\n    @range(min = -1)\n    1: Age uint8\n}\n", "min bound of annotation '@range' is not a valid uint8 value"},
+		{"unsatisfied default", "struct User {\n    @range(min = 21)\n    1: Age uint8 = 18\n}\n", "default value of field 'Age' does not satisfy annotation '@range'"},
+		{"unsatisfied argument default", "service Users {\n    Find(@range(min = 1) 1: page uint8 = 0) string\n}\n", "default value of argument 'page' does not satisfy annotation '@range'"},
+	})
+}
+
+func TestConstraintBounds(t *testing.T) {
+	decl := mustParseTestSource(t, `struct User {
+    @range(min = -10, max = 10.5)
+    1: Score float64
+
+    @length(max = 64)
+    @pattern("^[a-z]+$")
+    2: Login string
+}
+`)
+
+	score := decl.Structs["User"].Fields[0].Annotations.Constraints()
+	if score.Min != int64(-10) || score.Max != float64(10.5) {
+		t.Errorf("expected range -10 to 10.5 of Score, got %v to %v", score.Min, score.Max)
+	}
+
+	login := decl.Structs["User"].Fields[1].Annotations.Constraints()
+	if login.MinLength != nil || login.MaxLength != uint64(64) || login.Pattern != "^[a-z]+$" {
+		t.Errorf("expected maximum length 64 and pattern of Login, got %#v", login)
+	}
+}
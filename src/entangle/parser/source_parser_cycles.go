@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"fmt"
+	"strings"
+)
+
+// Name of the struct, union or type alias declared in the source referenced
+// by a non-nilable type.
+//
+// Returns an empty name for any other type, as these never contribute to the
+// size of a value.
+func referencedDeclarationName(typeDecl declarations.Type) string {
+	if typeDecl.Nilable() {
+		return ""
+	}
+
+	switch t := typeDecl.(type) {
+	case *declarations.StructType:
+		if t.Import() == nil {
+			return t.Struct().Name
+		}
+
+	case *declarations.UnionType:
+		if t.Import() == nil {
+			return t.Union().Name
+		}
+
+	case *declarations.TypedefType:
+		if t.Import() == nil {
+			return t.Typedef().Name
+		}
+	}
+
+	return ""
+}
+
+// Check that no type declared in the source is infinitely sized.
+//
+// A struct is infinitely sized if any of its non-nilable fields is, a union if
+// all of its members are and a type alias if the aliased type is. This is only
+// the case for types in cycles of non-nilable references, or types referencing
+// such cycles, and the first cycle in the order of the source is reported.
+func (p *sourceParser) checkInfiniteTypes() error {
+	// Determine the finitely sized types.
+	finite := make(map[string]bool)
+	isFinite := func(typeDecl declarations.Type) bool {
+		name := referencedDeclarationName(typeDecl)
+		return name == "" || finite[name]
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, tok := range p.typeDeclarationTokens {
+			name := tok.StringValue
+			if finite[name] {
+				continue
+			}
+
+			// The result is only recorded once all references have been
+			// checked, as a type may reference itself.
+			result := false
+
+			if structDecl, ok := p.decl.Structs[name]; ok {
+				result = true
+				for _, field := range structDecl.Fields {
+					if !isFinite(field.Type) {
+						result = false
+						break
+					}
+				}
+			} else if unionDecl, ok := p.decl.Unions[name]; ok {
+				for _, member := range unionDecl.Fields {
+					if isFinite(member.Type) {
+						result = true
+						break
+					}
+				}
+			} else if typedefDecl, ok := p.decl.Typedefs[name]; ok {
+				result = isFinite(typedefDecl.Type)
+			}
+
+			finite[name] = result
+			changed = changed || result
+		}
+	}
+
+	// Follow the references of infinitely sized types. Fields and members are
+	// followed in the order of their indexes, making the result deterministic.
+	nextInCycle := func(name string) (desc, next string) {
+		var fields []*declarations.Field
+
+		if structDecl, ok := p.decl.Structs[name]; ok {
+			fields = structDecl.FieldsSortedByIndex()
+		} else if unionDecl, ok := p.decl.Unions[name]; ok {
+			fields = unionDecl.FieldsSortedByIndex()
+		} else {
+			return name, referencedDeclarationName(p.decl.Typedefs[name].Type)
+		}
+
+		for _, field := range fields {
+			if !isFinite(field.Type) {
+				return fmt.Sprintf("%s.%s", name, field.Name), referencedDeclarationName(field.Type)
+			}
+		}
+
+		panic("infinitely sized type without infinitely sized references")
+	}
+
+	for _, tok := range p.typeDeclarationTokens {
+		name := tok.StringValue
+		if finite[name] {
+			continue
+		}
+
+		visited := make(map[string]bool)
+		cycle := make([]string, 0, 4)
+
+		for current := name; !visited[current]; {
+			visited[current] = true
+
+			var desc string
+			desc, current = nextInCycle(current)
+			cycle = append(cycle, desc)
+
+			if current == name {
+				cycle = append(cycle, name)
+				return p.parseErrorForToken(fmt.Sprintf("type '%s' is infinitely sized due to a cycle of non-nilable references: %s", name, strings.Join(cycle, " -> ")), &tok)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"testing"
+)
+
+func TestInfiniteTypes(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"forward reference", "struct A {\n    1: B B\n}\n\nstruct B {\n    1: Name string\n}\n", ""},
+		{"forward typedef", "struct A {\n    1: IDs IDs\n}\n\ntypedef IDs []uint64\n", ""},
+		{"nilable self reference", "struct Node {\n    1: Next *Node\n}\n", ""},
+		{"list self reference", "struct Node {\n    1: Children []Node\n}\n", ""},
+		{"map self reference", "struct Node {\n    1: Children map[string]Node\n}\n", ""},
+		{"nilable mutual reference", "struct A {\n    1: B B\n}\n\nstruct B {\n    1: A *A\n}\n", ""},
+		{"union with finite member", "union Expr {\n    1: Value int64\n    2: Negated Expr\n}\n", ""},
+		{"self reference", "struct S {\n    1: Value S\n}\n", "type 'S' is infinitely sized due to a cycle of non-nilable references: S.Value -> S"},
+		{"self reference after finite field", "struct S {\n    1: Name string\n    2: Value S\n}\n", "type 'S' is infinitely sized due to a cycle of non-nilable references: S.Value -> S"},
+		{"mutual reference", "struct A {\n    1: B B\n}\n\nstruct B {\n    1: A A\n}\n", "type 'A' is infinitely sized due to a cycle of non-nilable references: A.B -> B.A -> A"},
+		{"typedef reference", "typedef Alias S\n\nstruct S {\n    1: Value Alias\n}\n", "type 'Alias' is infinitely sized due to a cycle of non-nilable references: Alias -> S.Value -> Alias"},
+		{"union without finite member", "union Expr {\n    1: Negated Expr\n}\n", "type 'Expr' is infinitely sized due to a cycle of non-nilable references: Expr.Negated -> Expr"},
+		{"unknown type", "struct A {\n    1: B B\n}\n", "unknown type 'B'"},
+	})
+}
+
+func TestForwardReferences(t *testing.T) {
+	decl := mustParseTestSource(t, `struct Tree {
+    1: Root *Node
+}
+
+struct Node {
+    1: Children []Node
+    2: Parent *Node
+}
+`)
+
+	structType, ok := decl.Structs["Tree"].Fields[0].Type.(*declarations.StructType)
+	if !ok || structType.Struct() != decl.Structs["Node"] {
+		t.Errorf("expected Root of Tree to reference Node, got %v", decl.Structs["Tree"].Fields[0].Type)
+	}
+}
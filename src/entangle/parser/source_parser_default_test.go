@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"testing"
+)
+
+func TestDefaults(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"struct field", "struct Page {\n    1: Size uint32 = 50\n}\n", ""},
+		{"exception field", "exception Busy {\n    1: RetryAfter uint32 = 10\n}\n", ""},
+		{"argument", "service Lister {\n    List(1: size uint32 = 50) uint32\n}\n", ""},
+		{"enum", "enum Order {\n    1: Ascending\n}\n\nstruct Page {\n    1: Sort Order = Ascending\n}\n", ""},
+		{"typedef", "typedef Name string\n\nstruct Page {\n    1: Label Name = \"all\"\n}\n", ""},
+		{"nilable field", "struct Page {\n    1: Size *uint32 = 50\n}\n", "default values cannot be declared for nilable types"},
+		{"nilable argument", "service Lister {\n    List(1: size *uint32 = 50) uint32\n}\n", "default values cannot be declared for nilable types"},
+		{"union member", "union Value {\n    1: Count uint32 = 1\n}\n", "default values are not allowed in union member declaration"},
+		{"wrong type", "struct Page {\n    1: Size uint32 = \"50\"\n}\n", "expected unsigned integer as uint32 value"},
+		{"out of range", "struct Page {\n    1: Size uint8 = 256\n}\n", "value out of range for uint8"},
+		{"unknown enum value", "enum Order {\n    1: Ascending\n}\n\nstruct Page {\n    1: Sort Order = Descending\n}\n", "unknown value 'Descending' of enumeration 'Order'"},
+		{"list", "struct Page {\n    1: Sizes []uint32 = 1\n}\n", "values in struct field declaration must be of a bool, string, numeric or enumeration type"},
+		{"missing value", "struct Page {\n    1: Size uint32 =\n}\n", "unexpected end of line in struct field declaration, expected value"},
+	})
+}
+
+func TestDefaultValues(t *testing.T) {
+	decl := mustParseTestSource(t, `enum Order {
+    1: Ascending
+    2: Descending
+}
+
+struct Page {
+    1: Offset uint64
+    2: Size uint32 = 50
+    3: Sort Order = Descending
+}
+
+service Lister {
+    List(1: size uint32 = 20) uint32
+}
+`)
+
+	fields := decl.Structs["Page"].Fields
+
+	if fields[0].Default != nil {
+		t.Errorf("expected no default value of Offset, got %#v", fields[0].Default)
+	}
+
+	if fields[1].Default != uint64(50) {
+		t.Errorf("expected default value 50 of Size, got %#v", fields[1].Default)
+	}
+
+	if value, ok := fields[2].Default.(declarations.EnumValue); !ok || value.Name != "Descending" {
+		t.Errorf("expected default value Descending of Sort, got %#v", fields[2].Default)
+	}
+
+	function, _ := decl.Services["Lister"].Function("List")
+	if function.Arguments[0].Default != uint64(20) {
+		t.Errorf("expected default value 20 of size, got %#v", function.Arguments[0].Default)
+	}
+}
@@ -24,6 +24,10 @@ func (p *sourceParser) parseDefinition() (err error) {
 
 		p.decl.Name = p.tok.StringValue
 
+		if conflictingPath, conflict := p.resolver.registerDefinition(p.decl.Name, p.path); conflict {
+			return p.parseErrorHeref("definition name '%s' is already declared in %s", p.decl.Name, relativePath(p.path, conflictingPath))
+		}
+
 	default:
 		return p.parseErrorHere("expected definition name")
 	}
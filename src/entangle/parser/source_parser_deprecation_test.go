@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"testing"
+)
+
+func TestDeprecations(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"without reason", "@deprecated\nstruct Legacy {\n    1: Value string\n}\n", ""},
+		{"positional reason", "@deprecated(\"Use Level instead.\")\nenum Role {\n    1: Admin\n}\n", ""},
+		{"keyed reason", "enum Level {\n    @deprecated(reason = \"No longer used.\")\n    1: Mid\n}\n", ""},
+		{"function", "service Users {\n    @deprecated\n    Get(1: id int64) string\n}\n", ""},
+		{"non-string reason", "@deprecated(1)\nstruct Legacy {\n    1: Value string\n}\n", "annotation '@deprecated' takes an optional reason string as its only argument"},
+		{"unknown key", "@deprecated(why = \"Unused.\")\nstruct Legacy {\n    1: Value string\n}\n", "annotation '@deprecated' takes an optional reason string as its only argument"},
+		{"multiple reasons", "@deprecated(\"Unused.\", \"Really.\")\nstruct Legacy {\n    1: Value string\n}\n", "annotation '@deprecated' takes an optional reason string as its only argument"},
+	})
+}
+
+func TestDeprecatedReferences(t *testing.T) {
+	decl := mustParseTestSource(t, `@deprecated("Use Level instead.")
+enum Role {
+    1: Admin
+}
+
+@deprecated
+struct Legacy {
+    1: Role Role
+}
+
+struct User {
+    1: Role Role
+    2: Previous []Legacy
+}
+
+service Users {
+    Get(1: role Role) User
+
+    @deprecated
+    Fetch(1: role Role) Legacy
+}
+`)
+
+	expected := []declarations.DeprecatedReference{
+		{Referrer: "argument role of function Users.Get", Deprecated: "Role"},
+		{Referrer: "field User.Previous", Deprecated: "Legacy"},
+		{Referrer: "field User.Role", Deprecated: "Role"},
+	}
+
+	references := decl.DeprecatedReferences()
+	if len(references) != len(expected) {
+		t.Fatalf("expected %d deprecated references, got %v", len(expected), references)
+	}
+
+	for i, reference := range references {
+		if reference != expected[i] {
+			t.Errorf("expected deprecated reference %v, got %v", expected[i], reference)
+		}
+	}
+
+	if reason := decl.Enums["Role"].Annotations.DeprecationReason(); reason != "Use Level instead." {
+		t.Errorf("expected deprecation reason of Role, got '%s'", reason)
+	}
+}
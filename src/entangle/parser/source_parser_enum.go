@@ -3,13 +3,18 @@ package parser
 import (
 	"entangle/declarations"
 	"entangle/token"
+	"fmt"
 	"math"
 )
 
-// Parse an enumeration declaration.
-func (p *sourceParser) parseEnum() (err error) {
+// Parse an enumeration or flags declaration.
+func (p *sourceParser) parseEnum(flags bool) (err error) {
 	contextDesc := "enumeration declaration"
 	valueContextDesc := "enumeration value declaration"
+	if flags {
+		contextDesc = "flags declaration"
+		valueContextDesc = "flags value declaration"
+	}
 
 	// Parse the name.
 	var name string
@@ -52,119 +57,173 @@ func (p *sourceParser) parseEnum() (err error) {
 
 	// Let's initialize the declaration at this point.
 	decl := declarations.NewEnum(name, p.documentationParagraphs())
+	decl.Flags = flags
 	p.decl.MarkNameAsUsed(name)
 
+	target := enumTarget
+	if flags {
+		target = flagsTarget
+	}
+
+	if decl.Annotations, err = p.declarationAnnotations(target); err != nil {
+		return
+	}
+
 	// From here on out, we should be getting documentation and value
 	// definitions.
 	for {
-		if err = p.skipNewLinesStoreDocumentation(); err != nil {
-			return
-		}
-
-		// If we've reached a '}' here, let's break out of the loop.
-		if p.tok.Type == token.TokenType('}') {
+		var done bool
+		if done, err = p.parseEnumValue(decl, contextDesc, valueContextDesc); err != nil {
+			if err = p.recoverFromBodyError(err, token.UintConstant, token.IntConstant); err != nil {
+				return
+			}
+		} else if done {
 			break
 		}
+	}
 
-		// We should have an integer constant at this point.
-		var value int64
-
-		switch p.tok.Type {
-		case token.UintConstant:
-			if p.tok.UintValue > math.MaxInt64 {
-				return p.parseErrorHere("enumeration value out of range")
-			}
+	// Here, we should be met with a closing curly brace and a new line or
+	// end of file.
+	if err = p.expectRune('}', contextDesc); err != nil {
+		return
+	}
 
-			value = int64(p.tok.UintValue)
+	switch p.tok.Type {
+	case token.NewLine, token.EndOfFile:
+		break
 
-		case token.IntConstant:
-			value = p.tok.IntValue
+	default:
+		return p.parseErrorHere("expected new line following '}'")
+	}
 
-		case token.EndOfFile:
-			return p.parseErrorHeref("unexpected end of file in %s", contextDesc)
+	// Add the declaration to the interface declaration.
+	p.decl.AddEnum(decl)
 
-		default:
-			return p.parseErrorHere("expected field index")
-		}
+	return p.next()
+}
 
-		if enumValue, exists := decl.Values[value]; exists {
-			return p.parseErrorHeref("another enumeration value in '%s' already has this value: '%s'", name, enumValue.Name)
-		}
+// Parse an enumeration or flags value declaration.
+//
+// Returns whether the closing curly brace of the declaration was reached
+// instead.
+func (p *sourceParser) parseEnumValue(decl *declarations.Enum, contextDesc, valueContextDesc string) (done bool, err error) {
+	if err = p.skipNewLinesStoreDocumentation(); err != nil {
+		return
+	}
 
-		if err = p.next(); err != nil {
+	// Annotations precede the value.
+	if p.tok.Type == token.TokenType('@') {
+		if err = p.parseAnnotations(); err != nil {
 			return
 		}
+	}
 
-		// The unsigned integer should be followed by a colon (':').
-		if err = p.expectRune(':', valueContextDesc); err != nil {
-			return
+	// If we've reached a '}' here, we're done.
+	if p.tok.Type == token.TokenType('}') {
+		return true, p.checkNotAnnotated()
+	}
+
+	// We should have an integer constant at this point.
+	var value int64
+
+	switch p.tok.Type {
+	case token.UintConstant:
+		if p.tok.UintValue > math.MaxInt64 {
+			return false, p.parseErrorHere("enumeration value out of range")
 		}
 
-		// We should then get a name.
-		var name string
+		value = int64(p.tok.UintValue)
 
-		switch p.tok.Type {
-		case token.Identifier:
-			if err = p.validateEnumValueName(&p.tok); err != nil {
-				return
-			}
+	case token.IntConstant:
+		value = p.tok.IntValue
 
-			name = p.tok.StringValue
+	case token.EndOfFile:
+		return false, p.parseErrorHeref("unexpected end of file in %s", contextDesc)
 
-			if p.decl.NameInUse(name) {
-				return p.parseErrorHeref("enumeration value name '%s' would override previous type definition", name)
-			}
+	default:
+		return false, p.parseErrorHere("expected field index")
+	}
 
-		case token.NewLine:
-			return p.parseErrorHere("unexpected end of line in enumeration value declaration")
+	if decl.Flags && (value <= 0 || value&(value-1) != 0) {
+		return false, p.parseErrorHere("flags value must be a power of two")
+	}
 
-		case token.EndOfFile:
-			return p.parseErrorHere("unexpected end of file in enumeration value declaration")
+	if enumValue, exists := decl.Values[value]; exists {
+		return false, p.parseErrorHeref("another enumeration value in '%s' already has this value: '%s'", decl.Name, enumValue.Name)
+	}
 
-		default:
-			return p.parseErrorHere("expected name in enumeration value declaration")
-		}
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// The unsigned integer should be followed by a colon (':').
+	if err = p.expectRune(':', valueContextDesc); err != nil {
+		return
+	}
+
+	// We should then get a name.
+	var name string
 
-		if err = p.next(); err != nil {
+	switch p.tok.Type {
+	case token.Identifier:
+		if err = p.validateEnumValueName(&p.tok); err != nil {
 			return
 		}
 
-		// And then a new line.
-		switch p.tok.Type {
-		case token.NewLine:
-			break
-
-		case token.EndOfFile:
-			return p.parseErrorHere("unexpected end of file in enumeration declaration")
+		name = p.tok.StringValue
 
-		default:
-			return p.parseErrorHere("expected new line after enumeration value definition")
+		if p.decl.NameInUse(name) {
+			return false, p.parseErrorHeref("enumeration value name '%s' would override previous type definition", name)
 		}
 
-		// Add the field to the struct declaration.
-		decl.AddValue(value, name, p.documentationParagraphs())
+	case token.NewLine:
+		return false, p.parseErrorHere("unexpected end of line in enumeration value declaration")
 
-		if err = p.next(); err != nil {
-			return
-		}
+	case token.EndOfFile:
+		return false, p.parseErrorHere("unexpected end of file in enumeration value declaration")
+
+	default:
+		return false, p.parseErrorHere("expected name in enumeration value declaration")
 	}
 
-	// Here, we should be met with a closing curly brace and a new line or
-	// end of file.
-	if err = p.expectRune('}', contextDesc); err != nil {
+	if err = p.next(); err != nil {
 		return
 	}
 
+	// And then a new line.
 	switch p.tok.Type {
-	case token.NewLine, token.EndOfFile:
+	case token.NewLine:
 		break
 
+	case token.EndOfFile:
+		return false, p.parseErrorHere("unexpected end of file in enumeration declaration")
+
 	default:
-		return p.parseErrorHere("expected new line following '}'")
+		return false, p.parseErrorHere("expected new line after enumeration value definition")
 	}
 
-	// Add the declaration to the interface declaration.
-	p.decl.AddEnum(decl)
+	// Make sure a fallback value is allowed. Unknown values of flags
+	// and open enumerations are never replaced.
+	var annotations declarations.Annotations
+	if annotations, err = p.declarationAnnotations(enumValueTarget); err != nil {
+		return
+	}
 
-	return p.next()
+	if fallback := annotations.Annotation(declarations.FallbackAnnotation); fallback != nil {
+		span := p.declarationSpans[fallback]
+
+		if decl.Flags {
+			return false, p.parseError("fallback values are not allowed in flags", span.start, span.end)
+		} else if decl.Open() {
+			return false, p.parseError("fallback values are not allowed in open enumerations", span.start, span.end)
+		} else if previous := decl.Fallback(); previous != nil {
+			return false, p.parseError(fmt.Sprintf("another enumeration value in '%s' is already the fallback value: '%s'", decl.Name, previous.Name), span.start, span.end)
+		}
+	}
+
+	// Add the field to the struct declaration.
+	decl.AddValue(value, name, p.documentationParagraphs(), annotations)
+
+	err = p.next()
+	return
 }
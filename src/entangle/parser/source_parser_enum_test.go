@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestFlags(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"flags", "flags Permission {\n    1: Read\n    2: Write\n    4: Execute\n}\n", ""},
+		{"empty", "flags Empty {\n}\n", ""},
+		{"annotated", "@deprecated\nflags Permission {\n    1: Read\n}\n", ""},
+		{"constant and default", "flags Permission {\n    1: Read\n    2: Write\n}\n\nconst DefaultPermission Permission = Read\n\nstruct File {\n    1: Mode Permission = Write\n}\n", ""},
+		{"contextual keyword argument", "service Log {\n    Record(1: flags uint32) string\n}\n", ""},
+		{"zero", "flags Permission {\n    0: None\n}\n", "flags value must be a power of two"},
+		{"negative", "flags Permission {\n    -1: All\n}\n", "flags value must be a power of two"},
+		{"combination", "flags Permission {\n    1: Read\n    2: Write\n    3: ReadWrite\n}\n", "flags value must be a power of two"},
+		{"duplicate value", "flags Permission {\n    1: Read\n    1: View\n}\n", "another enumeration value in 'Permission' already has this value: 'Read'"},
+		{"missing name", "flags {\n    1: Read\n}\n", "expected enumeration name"},
+		{"misplaced keyword", "flag Permission {\n    1: Read\n}\n", "unexpected token"},
+	})
+}
+
+func TestFlagsDeclaration(t *testing.T) {
+	decl := mustParseTestSource(t, `enum Color {
+    1: Red
+}
+
+flags Permission {
+    1: Read
+    2: Write
+}
+`)
+
+	if decl.Enums["Color"].Flags {
+		t.Errorf("expected Color not to be flags")
+	}
+
+	permission := decl.Enums["Permission"]
+	if !permission.Flags || len(permission.Values) != 2 {
+		t.Errorf("expected Permission to be flags with 2 values")
+	}
+}
+
+func TestOpenEnums(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"open", "@open\nenum Color {\n    1: Red\n    2: Green\n}\n", ""},
+		{"open flags", "@open\nflags Feature {\n    1: Fast\n    2: Small\n}\n", ""},
+		{"fallback", "enum Shape {\n    @fallback\n    0: Unknown\n    1: Circle\n}\n", ""},
+		{"open with arguments", "@open(true)\nenum Color {\n    1: Red\n}\n", "annotation '@open' takes no arguments"},
+		{"fallback with arguments", "enum Shape {\n    @fallback(1)\n    0: Unknown\n}\n", "annotation '@fallback' takes no arguments"},
+		{"fallback in flags", "flags Feature {\n    @fallback\n    1: Fast\n}\n", "fallback values are not allowed in flags"},
+		{"fallback in open enum", "@open\nenum Shape {\n    @fallback\n    0: Unknown\n}\n", "fallback values are not allowed in open enumerations"},
+		{"second fallback", "enum Shape {\n    @fallback\n    0: Unknown\n    @fallback\n    1: Circle\n}\n", "another enumeration value in 'Shape' is already the fallback value: 'Unknown'"},
+	})
+}
+
+func TestEnumFallback(t *testing.T) {
+	decl := mustParseTestSource(t, `@open
+enum Color {
+    1: Red
+}
+
+enum Shape {
+    1: Circle
+
+    @fallback
+    0: Unknown
+}
+`)
+
+	if color := decl.Enums["Color"]; !color.Open() || color.Fallback() != nil {
+		t.Errorf("expected Color to be open without a fallback value")
+	}
+
+	shape := decl.Enums["Shape"]
+	if shape.Open() {
+		t.Errorf("expected Shape not to be open")
+	}
+
+	if fallback := shape.Fallback(); fallback == nil || fallback.Name != "Unknown" {
+		t.Errorf("expected fallback value Unknown of Shape, got %v", fallback)
+	}
+}
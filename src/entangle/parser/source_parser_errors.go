@@ -21,3 +21,18 @@ func (p *sourceParser) parseErrorHeref(description string, a ...interface{}) err
 func (p *sourceParser) parseErrorForToken(description string, tok *token.Token) error {
 	return errors.NewParseErrorForToken(description, tok, p.src, p.errorFrames)
 }
+
+func (p *sourceParser) parseErrorWithNote(description string, span sourceSpan, note string, noteSpan sourceSpan) error {
+	notes := []errors.ParseErrorNote{
+		{
+			ParseErrorFrame: errors.ParseErrorFrame{
+				Source: p.src,
+				Start:  noteSpan.start,
+				End:    noteSpan.end,
+			},
+			Description: note,
+		},
+	}
+
+	return errors.NewParseErrorWithNotes(description, span.start, span.end, notes, p.src, p.errorFrames)
+}
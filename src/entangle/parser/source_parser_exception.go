@@ -7,6 +7,9 @@ import (
 
 // Parse an exception declaration.
 func (p *sourceParser) parseException() (err error) {
+	contextDesc := "exception declaration"
+	fieldContextDesc := "exception field declaration"
+
 	if err = p.next(); err != nil {
 		return
 	}
@@ -33,24 +36,53 @@ func (p *sourceParser) parseException() (err error) {
 		}
 
 	default:
-		return p.parseErrorHere("expected struct name")
+		return p.parseErrorHere("expected exception name")
 	}
 
 	if err = p.next(); err != nil {
 		return
 	}
 
-	// The name should be followed by an end of line or file.
+	// The name is either followed by an end of line or file, or by an opening
+	// curly brace and field declarations.
+	decl := declarations.NewException(name, p.documentationParagraphs())
+	if decl.Annotations, err = p.declarationAnnotations(exceptionTarget); err != nil {
+		return
+	}
+
 	switch p.tok.Type {
 	case token.NewLine, token.EndOfFile:
 		break
 
+	case token.TokenType('{'):
+		if err = p.next(); err != nil {
+			return
+		}
+
+		if err = p.parseFields(&decl.FieldList, contextDesc, fieldContextDesc, true); err != nil {
+			return
+		}
+
+		// Here, we should be met with a closing curly brace and a new line
+		// or end of file.
+		if err = p.expectRune('}', contextDesc); err != nil {
+			return
+		}
+
+		switch p.tok.Type {
+		case token.NewLine, token.EndOfFile:
+			break
+
+		default:
+			return p.parseErrorHere("expected new line following '}'")
+		}
+
 	default:
-		return p.parseErrorHere("expected new line following exception declaration")
+		return p.parseErrorHere("expected new line or '{' following exception name")
 	}
 
-	// Create and add the exception declaration.
-	p.decl.AddException(declarations.NewException(name, p.documentationParagraphs()))
+	// Add the exception declaration.
+	p.decl.AddException(decl)
 
 	return p.next()
 }
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"testing"
+)
+
+func TestExceptionFields(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"without fields", "exception NotFound\n", ""},
+		{"empty", "exception NotFound {\n}\n", ""},
+		{"fields", "exception NotFound {\n    1: Key string\n    2: Attempts uint32\n}\n", ""},
+		{"sparse indexes", "exception NotFound {\n    1: Key string\n    3: Attempts uint32\n}\n", ""},
+		{"nilable", "exception NotFound {\n    1: Key *string\n}\n", ""},
+		{"default", "exception NotFound {\n    1: Attempts uint32 = 1\n}\n", ""},
+		{"declared types", "enum Kind {\n    1: File\n}\n\nstruct Location {\n    1: Path string\n}\n\nexception NotFound {\n    1: Kind Kind\n    2: Locations []Location\n}\n", ""},
+		{"forward reference", "exception NotFound {\n    1: Location Location\n}\n\nstruct Location {\n    1: Path string\n}\n", ""},
+		{"zero index", "exception NotFound {\n    0: Key string\n}\n", "field indexes are 1-based"},
+		{"duplicate index", "exception NotFound {\n    1: Key string\n    1: Path string\n}\n", "field index 1 already in use"},
+		{"duplicate name", "exception NotFound {\n    1: Key string\n    2: Key string\n}\n", "field name 'Key' already in use"},
+		{"unknown type", "exception NotFound {\n    1: Location Location\n}\n", "unknown type 'Location'"},
+		{"invalid name", "exception NotFound {\n    1: key string\n}\n", "'key' is not a valid field name. Field names must be upper camel case"},
+		{"duplicate exception", "exception NotFound\nexception NotFound\n", "exception name 'NotFound' would override previous type declaration"},
+	})
+}
+
+func TestExceptionFieldDeclarations(t *testing.T) {
+	decl := mustParseTestSource(t, `exception NotFound {
+    1: Key string
+    3: Attempts *uint32
+}
+`)
+
+	exc := decl.Exceptions["NotFound"]
+	fields := exc.FieldsSortedByIndex()
+	if len(fields) != 2 {
+		t.Fatalf("expected 2 fields of NotFound, got %d", len(fields))
+	}
+
+	if fields[0].Name != "Key" || fields[0].Index != 1 || fields[0].Type != declarations.StringType {
+		t.Errorf("expected field 1 Key of type string, got %d %s", fields[0].Index, fields[0].Name)
+	}
+
+	if fields[1].Name != "Attempts" || fields[1].Index != 3 || !fields[1].Type.Nilable() {
+		t.Errorf("expected field 3 Attempts of a nilable type, got %d %s", fields[1].Index, fields[1].Name)
+	}
+
+	if exc.SerializedLength() != 3 {
+		t.Errorf("expected serialized length 3 of NotFound, got %d", exc.SerializedLength())
+	}
+}
@@ -0,0 +1,203 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"entangle/token"
+	"fmt"
+)
+
+// Parse indexed field declarations.
+//
+// Invoked with the token following the opening curly brace as the current
+// token. Returns with the closing curly brace as the current token. Unless
+// optional fields are allowed, fields can neither be nilable nor have default
+// values.
+func (p *sourceParser) parseFields(fieldList *declarations.FieldList, contextDesc, fieldContextDesc string, optionalAllowed bool) (err error) {
+	reservations := &reservationContext{
+		list:        &fieldList.ReservationList,
+		desc:        "field",
+		contextDesc: fmt.Sprintf("reservation in %s", contextDesc),
+		declarationUsing: func(r *declarations.Reservation) interface{} {
+			if field := fieldList.FieldUsingReservation(r); field != nil {
+				return field
+			}
+
+			return nil
+		},
+	}
+
+	for {
+		var done bool
+		if done, err = p.parseField(fieldList, reservations, contextDesc, fieldContextDesc, optionalAllowed); err != nil {
+			// Reservations start with an identifier, as the reserved
+			// keyword is contextual.
+			if err = p.recoverFromBodyError(err, token.UintConstant, token.Identifier); err != nil {
+				return
+			}
+		} else if done {
+			return
+		}
+	}
+}
+
+// Parse an indexed field declaration or reservation.
+//
+// Returns whether the closing curly brace of the declaration was reached
+// instead.
+func (p *sourceParser) parseField(fieldList *declarations.FieldList, reservations *reservationContext, contextDesc, fieldContextDesc string, optionalAllowed bool) (done bool, err error) {
+	if err = p.skipNewLinesStoreDocumentation(); err != nil {
+		return
+	}
+
+	// Annotations precede the field.
+	if p.tok.Type == token.TokenType('@') {
+		if err = p.parseAnnotations(); err != nil {
+			return
+		}
+	}
+
+	// If we've reached a '}' here, we're done.
+	if p.tok.Type == token.TokenType('}') {
+		return true, p.checkNotAnnotated()
+	}
+
+	// Reservations are followed by a new line.
+	if p.atKeyword(reservedKeyword) {
+		if err = p.checkNotAnnotated(); err != nil {
+			return
+		}
+
+		if _, err = p.parseReservation(reservations); err != nil {
+			return
+		}
+
+		switch p.tok.Type {
+		case token.NewLine:
+			break
+
+		case token.EndOfFile:
+			return false, p.parseErrorHeref("unexpected end of file in %s", contextDesc)
+
+		default:
+			return false, p.parseErrorHeref("expected new line after %s", reservations.contextDesc)
+		}
+
+		err = p.next()
+		return
+	}
+
+	// We should have an unsigned integer constant at this point.
+	var fieldIndex uint
+	span := tokenSpan(&p.tok)
+
+	switch p.tok.Type {
+	case token.UintConstant:
+		fieldIndex = uint(p.tok.UintValue)
+
+		if fieldIndex == 0 {
+			return false, p.parseErrorHere("field indexes are 1-based")
+		} else if fieldList.FieldIndexInUse(fieldIndex) {
+			return false, p.parseErrorHeref("field index %d already in use", fieldIndex)
+		} else if err = p.checkIndexNotReserved(reservations, fieldIndex, &p.tok); err != nil {
+			return
+		}
+
+	case token.EndOfFile:
+		return false, p.parseErrorHeref("unexpected end of file in %s", contextDesc)
+
+	default:
+		return false, p.parseErrorHere("expected field index")
+	}
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// The unsigned integer should be followed by a colon (':').
+	if err = p.expectRune(':', fieldContextDesc); err != nil {
+		return
+	}
+
+	// We should then get a name.
+	var name string
+
+	switch p.tok.Type {
+	case token.Identifier:
+		if err = p.validateFieldName(&p.tok); err != nil {
+			return
+		}
+
+		name = p.tok.StringValue
+
+		if fieldList.FieldNameInUse(name) {
+			return false, p.parseErrorHeref("field name '%s' already in use", name)
+		} else if err = p.checkNameNotReserved(reservations, name, &p.tok); err != nil {
+			return
+		}
+
+		span.end = p.tok.End
+
+	case token.NewLine:
+		return false, p.parseErrorHeref("unexpected end of line in %s", fieldContextDesc)
+
+	case token.EndOfFile:
+		return false, p.parseErrorHeref("unexpected end of file in %s", fieldContextDesc)
+
+	default:
+		return false, p.parseErrorHeref("expected field name in %s", fieldContextDesc)
+	}
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// Then a type.
+	var fieldType declarations.Type
+	if fieldType, err = p.parseType(fieldContextDesc); err != nil {
+		return
+	}
+
+	if !optionalAllowed && fieldType.Nilable() {
+		return false, p.parseErrorHeref("nilable types are not allowed in %s", fieldContextDesc)
+	}
+
+	// Add the field to the declaration.
+	field := fieldList.AddField(fieldIndex, name, p.documentationParagraphs(), fieldType)
+	p.declarationSpans[field] = span
+
+	if field.Annotations, err = p.declarationAnnotations(fieldTarget); err != nil {
+		return
+	}
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// Optionally followed by a default value.
+	if !optionalAllowed && p.tok.Type == token.TokenType('=') {
+		return false, p.parseErrorHeref("default values are not allowed in %s", fieldContextDesc)
+	}
+
+	if err = p.parseDefaultValue(fieldType, fieldContextDesc, &field.Default); err != nil {
+		return
+	}
+
+	if err = p.checkConstraints(field.Annotations, fieldType, &field.Default, fmt.Sprintf("field '%s'", name)); err != nil {
+		return
+	}
+
+	// And then a new line.
+	switch p.tok.Type {
+	case token.NewLine:
+		break
+
+	case token.EndOfFile:
+		return false, p.parseErrorHeref("unexpected end of file in %s", contextDesc)
+
+	default:
+		return false, p.parseErrorHeref("expected new line after %s", fieldContextDesc)
+	}
+
+	err = p.next()
+	return
+}
@@ -6,6 +6,27 @@ import (
 	"unicode"
 )
 
+// Contextual keywords.
+//
+// Contextual keywords are lexed as identifiers and only carry a meaning in
+// specific positions, leaving them valid names of fields, arguments and
+// values.
+const (
+	flagsKeyword     = "flags"
+	setKeyword       = "set"
+	timestampKeyword = "timestamp"
+	durationKeyword  = "duration"
+	onewayKeyword    = "oneway"
+	throwsKeyword    = "throws"
+	unionKeyword     = "union"
+	reservedKeyword  = "reserved"
+)
+
+// Determine if the current token is a contextual keyword.
+func (p *sourceParser) atKeyword(keyword string) bool {
+	return p.tok.Type == token.Identifier && p.tok.StringValue == keyword
+}
+
 // Expect a rune.
 //
 // If the rune is found, the source is moved ahead one token as the rune has
@@ -17,10 +38,10 @@ func (p *sourceParser) expectRune(r rune, contextDesc string) (err error) {
 		return p.next()
 
 	case token.NewLine:
-		return p.parseErrorHeref("unexpected new line in %s, expected '%s'", contextDesc, r)
+		return p.parseErrorHeref("unexpected new line in %s, expected '%s'", contextDesc, string(r))
 
 	case token.EndOfFile:
-		return p.parseErrorHeref("unexpected end of file in %s, expected '%s'", contextDesc, r)
+		return p.parseErrorHeref("unexpected end of file in %s, expected '%s'", contextDesc, string(r))
 
 	default:
 		return p.parseErrorHeref("expected '%s' in %s", string(r), contextDesc)
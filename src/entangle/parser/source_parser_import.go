@@ -1,7 +1,11 @@
 package parser
 
 import (
+	"entangle/declarations"
+	"entangle/errors"
+	"entangle/source"
 	"entangle/token"
+	"fmt"
 	"strings"
 )
 
@@ -22,6 +26,10 @@ func (p *sourceParser) parseImport() (err error) {
 
 		importName = p.tok.StringValue
 
+		if p.decl.NameInUse(importName) {
+			return p.parseErrorHeref("import name '%s' would override previous declaration", importName)
+		}
+
 		if err = p.next(); err != nil {
 			return
 		}
@@ -52,9 +60,67 @@ func (p *sourceParser) parseImport() (err error) {
 		}
 	}
 
+	end := p.tok.End
+
 	if err = p.next(); err != nil {
 		return
 	}
 
-	return p.parseError("imports are currently not supported", start, p.tok.End)
+	// The import path should be followed by an end of line or file.
+	switch p.tok.Type {
+	case token.NewLine, token.EndOfFile:
+		break
+
+	default:
+		return p.parseErrorHere("expected new line following import statement")
+	}
+
+	// Resolve the import.
+	resolvedPath, absPath, found := p.resolver.resolve(path, p.src.Path())
+	if !found {
+		return p.parseError(fmt.Sprintf("cannot find import '%s'", path), start, end)
+	}
+
+	if cycle, found := p.resolver.cycle(absPath); found {
+		for i, cyclePath := range cycle {
+			cycle[i] = relativePath(p.path, cyclePath)
+		}
+
+		return p.parseError(fmt.Sprintf("import cycle: %s", strings.Join(cycle, " -> ")), start, end)
+	}
+
+	// Parse the imported file unless it has already been parsed.
+	interfaceDecl, parsed := p.resolver.interfaces[absPath]
+
+	if !parsed {
+		var src *source.Source
+		if src, err = source.FromFile(resolvedPath); err != nil {
+			return p.parseError(fmt.Sprintf("failed to read import '%s': %v", path, err), start, end)
+		}
+
+		errorFrames := make([]errors.ParseErrorFrame, len(p.errorFrames), len(p.errorFrames)+1)
+		copy(errorFrames, p.errorFrames)
+		errorFrames = append(errorFrames, errors.ParseErrorFrame{
+			Source: p.src,
+			Start:  start,
+			End:    end,
+		})
+
+		if interfaceDecl, err = p.resolver.parse(src, errorFrames); err != nil {
+			return
+		}
+	}
+
+	// Unnamed imports are named after the imported definition.
+	if importName == "" {
+		importName = interfaceDecl.Name
+
+		if p.decl.NameInUse(importName) {
+			return p.parseError(fmt.Sprintf("import name '%s' would override previous declaration", importName), start, end)
+		}
+	}
+
+	p.decl.AddImport(declarations.NewImport(importName, path, interfaceDecl))
+
+	return p.next()
 }
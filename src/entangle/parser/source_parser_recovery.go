@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"entangle/errors"
+	"entangle/token"
+)
+
+// Token types starting a declaration at the beginning of a line.
+//
+// Flags and union declarations start with contextual keywords instead.
+var declarationStartTokens = map[token.TokenType]bool{
+	token.Import:    true,
+	token.Const:     true,
+	token.Typedef:   true,
+	token.Struct:    true,
+	token.Exception: true,
+	token.Enum:      true,
+	token.Service:   true,
+}
+
+// Record an error to recover from.
+//
+// Parse error lists are recorded as their errors, and errors already recorded
+// are ignored. Errors other than parse errors cannot be recovered from and are
+// returned.
+func (p *sourceParser) recordError(err error) error {
+	parseErr, ok := err.(errors.ParseError)
+	if !ok {
+		return err
+	}
+
+	for _, e := range errors.ParseErrors(parseErr) {
+		recorded := false
+		for _, previous := range p.parseErrors {
+			if previous == e {
+				recorded = true
+				break
+			}
+		}
+
+		if !recorded {
+			p.parseErrors = append(p.parseErrors, e)
+		}
+	}
+
+	return nil
+}
+
+// Skip the current token while recovering from an error.
+//
+// Errors of the lexer are recorded.
+func (p *sourceParser) skipToken() {
+	if err := p.next(); err != nil {
+		p.recordError(err)
+	}
+}
+
+// Test if the current token starts a declaration.
+func (p *sourceParser) atDeclarationStart() bool {
+	return p.prev.Type == token.NewLine && (declarationStartTokens[p.tok.Type] || p.atKeyword(flagsKeyword) || p.atKeyword(unionKeyword))
+}
+
+// Recover from an error in a declaration.
+//
+// Records the error and skips to the next line starting with a declaration,
+// other than the one started at the given position.
+func (p *sourceParser) recoverFromDeclarationError(err error, start token.Position) error {
+	if err = p.recordError(err); err != nil {
+		return err
+	}
+
+	p.annotations = nil
+	p.documentationLines = []token.Token{}
+
+	for p.tok.Type != token.EndOfFile && (p.tok.Start == start || !p.atDeclarationStart()) {
+		p.skipToken()
+	}
+
+	return nil
+}
+
+// Recover from an error in the body of a declaration.
+//
+// Records the error and skips to the closing curly brace of the body or the
+// next line starting with one of the given token types. If the body is left
+// unterminated by the end of the file or the start of another declaration,
+// the error is returned for recovery at the declaration level.
+func (p *sourceParser) recoverFromBodyError(err error, types ...token.TokenType) error {
+	if recordErr := p.recordError(err); recordErr != nil {
+		return recordErr
+	}
+
+	p.annotations = nil
+
+	for skipped := false; ; skipped = true {
+		if p.tok.Type == token.EndOfFile || p.atDeclarationStart() {
+			return err
+		}
+
+		if p.prev.Type == token.NewLine {
+			if p.tok.Type == token.TokenType('}') {
+				return nil
+			}
+
+			for _, t := range types {
+				if skipped && p.tok.Type == t {
+					return nil
+				}
+			}
+		}
+
+		p.skipToken()
+	}
+}
@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"entangle/errors"
+	"entangle/source"
+	"testing"
+)
+
+func TestErrorRecovery(t *testing.T) {
+	src, err := source.FromString(`definition recovery
+
+struct A {
+    1: Name string
+    1: Other string
+    2: Valid string = "unterminated
+    3: Last string
+}
+
+strukt B {
+    1: Name string
+}
+
+enum C {
+    1: Red
+    1: Blue
+}
+
+service D {
+    Get(1: key string) string
+    Put(1: key string, 2: value) string
+}
+
+flags F {
+    3: Both
+}
+
+union U {
+    1: Text string
+    2: Number *int64
+}
+
+struct E {
+    1: Name string
+`, "recovery.etg")
+	if err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+
+	_, err = Parse(src)
+
+	parseErr, ok := err.(errors.ParseError)
+	if !ok {
+		t.Fatalf("expected parse error, got %v", err)
+	}
+
+	expected := []struct {
+		Line        int
+		Description string
+	}{
+		{5, "field index 1 already in use"},
+		{6, "unexpected end of line in literal"},
+		{10, "unexpected token"},
+		{16, "another enumeration value in 'C' already has this value: 'Red'"},
+		{21, "expected type in service function argument declaration"},
+		{25, "flags value must be a power of two"},
+		{30, "nilable types are not allowed in union member declaration"},
+		{34, "unexpected end of file in struct declaration"},
+	}
+
+	errs := errors.ParseErrors(parseErr)
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %d", len(expected), len(errs))
+	}
+
+	for i, e := range errs {
+		if line := e.Frames()[0].Start.Line; line != expected[i].Line || e.Description() != expected[i].Description {
+			t.Errorf("expected error '%s' on line %d, got '%s' on line %d", expected[i].Description, expected[i].Line, e.Description(), line)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"entangle/token"
+	"fmt"
+)
+
+// Source span.
+type sourceSpan struct {
+	start token.Position
+	end   token.Position
+}
+
+// Source span of a token.
+func tokenSpan(tok *token.Token) sourceSpan {
+	return sourceSpan{
+		start: tok.Start,
+		end:   tok.End,
+	}
+}
+
+// Reservation context.
+//
+// Describes the declarations subject to reservations, i.e. either the fields
+// of a field list or the arguments of a function.
+type reservationContext struct {
+	// Reservation list.
+	list *declarations.ReservationList
+
+	// Description of the reserved declarations, e.g. "field".
+	desc string
+
+	// Description of the context of the reservation statement.
+	contextDesc string
+
+	// Find the declaration using a reservation.
+	//
+	// Returns nil if no declaration uses the reservation.
+	declarationUsing func(r *declarations.Reservation) interface{}
+}
+
+// Check that the index of a declaration is not reserved.
+func (p *sourceParser) checkIndexNotReserved(ctx *reservationContext, index uint, tok *token.Token) error {
+	if r := ctx.list.IndexReservation(index, index); r != nil {
+		return p.parseErrorWithNote(fmt.Sprintf("%s index %d is reserved", ctx.desc, index), tokenSpan(tok), "reserved here", p.declarationSpans[r])
+	}
+
+	return nil
+}
+
+// Check that the name of a declaration is not reserved.
+func (p *sourceParser) checkNameNotReserved(ctx *reservationContext, name string, tok *token.Token) error {
+	if r := ctx.list.NameReservation(name); r != nil {
+		return p.parseErrorWithNote(fmt.Sprintf("%s name '%s' is reserved", ctx.desc, name), tokenSpan(tok), "reserved here", p.declarationSpans[r])
+	}
+
+	return nil
+}
+
+// Parse a reservation statement.
+//
+// Invoked with the reserved keyword as the current token. Reservations are
+// separated by commas, and the statement ends at the first token following a
+// reservation other than a comma. Returns with that token as the current
+// token.
+//
+// In argument lists, the statement also ends at a comma followed by the
+// closing parenthesis or the next argument, in which case stop is set and the
+// token following the comma is the current token when returning.
+func (p *sourceParser) parseReservation(ctx *reservationContext) (stop bool, err error) {
+	if err = p.next(); err != nil {
+		return
+	}
+
+	for {
+		var r *declarations.Reservation
+		span := tokenSpan(&p.tok)
+
+		switch p.tok.Type {
+		case token.UintConstant:
+			first := uint(p.tok.UintValue)
+			last := first
+
+			if first == 0 {
+				return false, p.parseErrorHeref("%s indexes are 1-based", ctx.desc)
+			}
+
+			if err = p.next(); err != nil {
+				return
+			}
+
+			// The index is optionally followed by 'to' and the last index of
+			// a range.
+			if p.tok.Type == token.Identifier && p.tok.StringValue == "to" {
+				if err = p.next(); err != nil {
+					return
+				}
+
+				if p.tok.Type != token.UintConstant {
+					return false, p.parseErrorHeref("expected last index of reserved range in %s", ctx.contextDesc)
+				}
+
+				last = uint(p.tok.UintValue)
+				span.end = p.tok.End
+
+				if last < first {
+					return false, p.parseErrorForToken(fmt.Sprintf("last index of reserved range %d to %d precedes the first", first, last), &p.tok)
+				}
+
+				if err = p.next(); err != nil {
+					return
+				}
+			}
+
+			if existing := ctx.list.IndexReservation(first, last); existing != nil {
+				return false, p.parseErrorWithNote(fmt.Sprintf("%s indexes already reserved", ctx.desc), span, "previously reserved here", p.declarationSpans[existing])
+			}
+
+			r = ctx.list.AddIndexReservation(first, last)
+
+		case token.Literal:
+			name := p.tok.StringValue
+
+			if existing := ctx.list.NameReservation(name); existing != nil {
+				return false, p.parseErrorWithNote(fmt.Sprintf("%s name '%s' already reserved", ctx.desc, name), span, "previously reserved here", p.declarationSpans[existing])
+			}
+
+			r = ctx.list.AddNameReservation(name)
+
+			if err = p.next(); err != nil {
+				return
+			}
+
+		case token.NewLine:
+			return false, p.parseErrorHeref("unexpected end of line in %s, expected reserved index or name", ctx.contextDesc)
+
+		case token.EndOfFile:
+			return false, p.parseErrorHeref("unexpected end of file in %s", ctx.contextDesc)
+
+		default:
+			return false, p.parseErrorHeref("expected reserved index or name in %s", ctx.contextDesc)
+		}
+
+		p.declarationSpans[r] = span
+
+		// Make sure that no previous declaration uses the reservation.
+		if decl := ctx.declarationUsing(r); decl != nil {
+			return false, p.parseErrorWithNote(fmt.Sprintf("reserved %s is already in use", ctx.desc), span, fmt.Sprintf("%s declared here", ctx.desc), p.declarationSpans[decl])
+		}
+
+		if p.tok.Type != token.TokenType(',') {
+			return
+		}
+
+		if err = p.nextAndSkipNewLines(); err != nil {
+			return
+		}
+
+		// In argument lists, the comma may also be followed by the closing
+		// parenthesis, or by documentation or an index followed by a colon
+		// starting the next argument.
+		if p.tok.Type == token.TokenType(')') || p.tok.Type == token.DocumentationLine {
+			return true, nil
+		} else if p.tok.Type == token.UintConstant {
+			var following token.Token
+			if following, err = p.peek(); err != nil {
+				return
+			}
+
+			if following.Type == token.TokenType(':') {
+				return true, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestReservations(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"struct", "struct Account {\n    1: ID int64\n    reserved 2, 5 to 7\n    reserved \"Email\"\n    3: Name string\n}\n", ""},
+		{"exception", "exception Failed {\n    reserved 1\n    2: Reason string\n}\n", ""},
+		{"arguments", "service Accounts {\n    Get(1: id int64, reserved 2, \"legacy\", 3: full bool) string\n}\n", ""},
+		{"union", "union Value {\n    reserved 1\n    2: Text string\n}\n", ""},
+		{"argument named reserved", "service Accounts {\n    Get(1: id int64, 2: reserved bool) string\n}\n", ""},
+		{"reserved argument named reserved", "service Accounts {\n    Get(reserved 2, \"reserved\", 1: id int64) string\n}\n", ""},
+		{"reserved index", "struct Account {\n    reserved 2\n    2: Name string\n}\n", "field index 2 is reserved"},
+		{"reserved range", "struct Account {\n    reserved 2 to 4\n    3: Name string\n}\n", "field index 3 is reserved"},
+		{"reserved name", "struct Account {\n    reserved \"Email\"\n    1: Email string\n}\n", "field name 'Email' is reserved"},
+		{"index in use", "struct Account {\n    1: Name string\n    reserved 1\n}\n", "reserved field is already in use"},
+		{"name in use", "struct Account {\n    1: Email string\n    reserved \"Email\"\n}\n", "reserved field is already in use"},
+		{"reserved argument index", "service Accounts {\n    Get(reserved 1, 1: id int64) string\n}\n", "argument index 1 is reserved"},
+		{"reserved argument name", "service Accounts {\n    Get(reserved \"id\", 1: id int64) string\n}\n", "argument name 'id' is reserved"},
+		{"zero index", "struct Account {\n    reserved 0\n}\n", "field indexes are 1-based"},
+		{"reversed range", "struct Account {\n    reserved 4 to 2\n}\n", "last index of reserved range 4 to 2 precedes the first"},
+		{"overlapping ranges", "struct Account {\n    reserved 2 to 4\n    reserved 3\n}\n", "field indexes already reserved"},
+		{"duplicate name", "struct Account {\n    reserved \"Email\", \"Email\"\n}\n", "field name 'Email' already reserved"},
+		{"missing index", "struct Account {\n    reserved\n}\n", "unexpected end of line in reservation in struct declaration, expected reserved index or name"},
+		{"missing last index", "struct Account {\n    reserved 2 to\n}\n", "expected last index of reserved range in reservation in struct declaration"},
+	})
+}
@@ -0,0 +1,223 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"entangle/token"
+	"fmt"
+)
+
+// Type class of unresolved types.
+//
+// Never exposed outside of the parser, as all unresolved types are replaced
+// once the source has been parsed.
+const unresolvedClass declarations.TypeClass = -1
+
+// Unresolved type.
+//
+// Placeholder for a reference to a type, which has not been declared at the
+// time the reference is parsed.
+type unresolvedType struct {
+	tok     token.Token
+	nilable bool
+}
+
+func (t *unresolvedType) Class() declarations.TypeClass {
+	return unresolvedClass
+}
+
+func (t *unresolvedType) Nilable() bool {
+	return t.nilable
+}
+
+// Determine if a type is resolved.
+//
+// A type is unresolved if it is, aliases or contains an unresolved type.
+func typeResolved(typeDecl declarations.Type) bool {
+	switch t := typeDecl.(type) {
+	case *unresolvedType:
+		return false
+
+	case *declarations.TypedefType:
+		return typeResolved(t.Typedef().Type)
+
+	case *declarations.ListType:
+		return typeResolved(t.ElementType())
+
+	case *declarations.SetType:
+		return typeResolved(t.ElementType())
+
+	case *declarations.MapType:
+		return typeResolved(t.KeyType()) && typeResolved(t.ValueType())
+
+	default:
+		return true
+	}
+}
+
+// Types referenced by the signature of a function.
+func signatureTypes(decl *declarations.Function) []declarations.Type {
+	types := make([]declarations.Type, 0, len(decl.Arguments)+1)
+
+	for _, arg := range decl.Arguments {
+		types = append(types, arg.Type)
+	}
+
+	if decl.ReturnType != nil {
+		types = append(types, decl.ReturnType)
+	}
+
+	return types
+}
+
+// Look up a type declared in the source by name.
+func (p *sourceParser) lookupType(name string, nilable bool) (decl declarations.Type, found bool) {
+	if structDecl, ok := p.decl.Structs[name]; ok {
+		return declarations.NewStructType(structDecl, nilable), true
+	} else if enumDecl, ok := p.decl.Enums[name]; ok {
+		return declarations.NewEnumType(enumDecl, nilable), true
+	} else if unionDecl, ok := p.decl.Unions[name]; ok {
+		return declarations.NewUnionType(unionDecl, nilable), true
+	} else if typedefDecl, ok := p.decl.Typedefs[name]; ok {
+		return declarations.NewTypedefType(typedefDecl, nilable), true
+	}
+
+	return nil, false
+}
+
+// Resolve a type.
+//
+// Returns the type itself if it does not contain any unresolved types.
+func (p *sourceParser) resolveType(typeDecl declarations.Type) (resolved declarations.Type, err error) {
+	switch t := typeDecl.(type) {
+	case *unresolvedType:
+		var found bool
+		if resolved, found = p.lookupType(t.tok.StringValue, t.nilable); !found {
+			return nil, p.parseErrorForToken(fmt.Sprintf("unknown type '%s'", t.tok.StringValue), &t.tok)
+		}
+
+		return
+
+	case *declarations.ListType:
+		var elementType declarations.Type
+		if elementType, err = p.resolveType(t.ElementType()); err != nil || elementType == t.ElementType() {
+			return t, err
+		}
+
+		return declarations.NewListType(elementType, t.Nilable()), nil
+
+	case *declarations.SetType:
+		var elementType declarations.Type
+		if elementType, err = p.resolveType(t.ElementType()); err != nil || elementType == t.ElementType() {
+			return t, err
+		}
+
+		return declarations.NewSetType(elementType, t.Nilable()), nil
+
+	case *declarations.MapType:
+		var keyType, valueType declarations.Type
+		if keyType, err = p.resolveType(t.KeyType()); err != nil {
+			return
+		}
+		if valueType, err = p.resolveType(t.ValueType()); err != nil {
+			return
+		}
+
+		if keyType == t.KeyType() && valueType == t.ValueType() {
+			return t, nil
+		}
+
+		return declarations.NewMapType(keyType, valueType, t.Nilable()), nil
+
+	default:
+		return t, nil
+	}
+}
+
+// Run a check once types are resolved.
+//
+// The check is run immediately if all of the types are resolved. Otherwise,
+// it is deferred until all types of the source have been resolved.
+func (p *sourceParser) whenResolved(check func() error, types ...declarations.Type) error {
+	for _, t := range types {
+		if t != nil && !typeResolved(t) {
+			p.deferredChecks = append(p.deferredChecks, check)
+			return nil
+		}
+	}
+
+	return check()
+}
+
+// Resolve all types of the source.
+//
+// Invoked once the whole source has been parsed. Replaces unresolved types in
+// all declarations, makes sure that no type is infinitely sized and finally
+// runs any deferred checks.
+func (p *sourceParser) resolve() (err error) {
+	// Report references to unknown types in the order of the source.
+	for _, t := range p.unresolvedTypes {
+		if _, found := p.lookupType(t.tok.StringValue, t.nilable); !found {
+			return p.parseErrorForToken(fmt.Sprintf("unknown type '%s'", t.tok.StringValue), &t.tok)
+		}
+	}
+
+	for _, constDecl := range p.decl.Constants {
+		if constDecl.Type, err = p.resolveType(constDecl.Type); err != nil {
+			return
+		}
+	}
+
+	for _, typedefDecl := range p.decl.Typedefs {
+		if typedefDecl.Type, err = p.resolveType(typedefDecl.Type); err != nil {
+			return
+		}
+	}
+
+	fieldLists := make([]*declarations.FieldList, 0, len(p.decl.Structs)+len(p.decl.Exceptions)+len(p.decl.Unions))
+	for _, structDecl := range p.decl.Structs {
+		fieldLists = append(fieldLists, &structDecl.FieldList)
+	}
+	for _, excDecl := range p.decl.Exceptions {
+		fieldLists = append(fieldLists, &excDecl.FieldList)
+	}
+	for _, unionDecl := range p.decl.Unions {
+		fieldLists = append(fieldLists, &unionDecl.FieldList)
+	}
+
+	for _, fieldList := range fieldLists {
+		for _, field := range fieldList.Fields {
+			if field.Type, err = p.resolveType(field.Type); err != nil {
+				return
+			}
+		}
+	}
+
+	for _, serviceDecl := range p.decl.Services {
+		for _, functionDecl := range serviceDecl.Functions {
+			for _, arg := range functionDecl.Arguments {
+				if arg.Type, err = p.resolveType(arg.Type); err != nil {
+					return
+				}
+			}
+
+			if functionDecl.ReturnType != nil {
+				if functionDecl.ReturnType, err = p.resolveType(functionDecl.ReturnType); err != nil {
+					return
+				}
+			}
+		}
+	}
+
+	if err = p.checkInfiniteTypes(); err != nil {
+		return
+	}
+
+	for _, check := range p.deferredChecks {
+		if err = check(); err != nil {
+			return
+		}
+	}
+
+	p.deferredChecks = nil
+	return
+}
@@ -3,6 +3,7 @@ package parser
 import (
 	"entangle/declarations"
 	"entangle/token"
+	"fmt"
 )
 
 // Parse a service declaration.
@@ -86,6 +87,10 @@ func (p *sourceParser) parseService() (err error) {
 		decl = declarations.NewService(name, documentation)
 	}
 
+	if decl.Annotations, err = p.declarationAnnotations(serviceTarget); err != nil {
+		return
+	}
+
 	// From here on out, we should be getting documentation and field
 	// definitions.
 	for {
@@ -93,15 +98,34 @@ func (p *sourceParser) parseService() (err error) {
 			return
 		}
 
+		// Annotations precede the function.
+		if p.tok.Type == token.TokenType('@') {
+			if err = p.parseAnnotations(); err != nil {
+				if err = p.recoverFromBodyError(err, token.Identifier); err != nil {
+					return
+				}
+
+				continue
+			}
+		}
+
 		// If we've reached a '}' here, let's break out of the loop.
 		if p.tok.Type == token.TokenType('}') {
+			if err = p.checkNotAnnotated(); err != nil {
+				return
+			}
+
 			break
 		}
 
 		// Parse the function.
 		var function *declarations.Function
 		if function, err = p.parseServiceFunction(decl); err != nil {
-			return
+			if err = p.recoverFromBodyError(err, token.Identifier); err != nil {
+				return
+			}
+
+			continue
 		}
 
 		decl.AddFunction(function)
@@ -132,8 +156,28 @@ func (p *sourceParser) parseServiceFunction(serviceDecl *declarations.Service) (
 	contextDesc := "service function definition"
 	argumentContextDesc := "service function argument declaration"
 
+	// The function may be declared one-way, in which case the keyword is
+	// followed by the name of the function.
+	oneway := false
+	if p.atKeyword(onewayKeyword) {
+		var following token.Token
+		if following, err = p.peek(); err != nil {
+			return
+		}
+
+		if following.Type == token.Identifier {
+			oneway = true
+
+			if err = p.next(); err != nil {
+				return
+			}
+		}
+	}
+
 	// Parse the name.
 	var name string
+	var nameTok token.Token
+	var inheritedDecl *declarations.Function
 
 	switch p.tok.Type {
 	case token.NewLine:
@@ -148,9 +192,20 @@ func (p *sourceParser) parseServiceFunction(serviceDecl *declarations.Service) (
 		}
 
 		name = p.tok.StringValue
+		nameTok = p.tok
+
+		// Functions inherited from the parent service may be redeclared
+		// once, provided that the signature is unchanged.
+		if existingDecl, found := serviceDecl.Function(name); found {
+			if !serviceDecl.FunctionInherited(name) {
+				return nil, p.parseErrorHeref("function name '%s' has already been declared", name)
+			}
+
+			if parentFunctionDecl, _ := serviceDecl.Parent.Function(name); parentFunctionDecl != existingDecl {
+				return nil, p.parseErrorHeref("function name '%s' has already been declared", name)
+			}
 
-		if serviceDecl.FunctionNameInUse(name) {
-			return nil, p.parseErrorHeref("function name '%s' has already been declared", name)
+			inheritedDecl = existingDecl
 		}
 
 	default:
@@ -163,6 +218,11 @@ func (p *sourceParser) parseServiceFunction(serviceDecl *declarations.Service) (
 
 	// Create the function declaration.
 	decl = declarations.NewFunction(name, p.documentationParagraphs())
+	decl.Oneway = oneway
+
+	if decl.Annotations, err = p.declarationAnnotations(functionTarget); err != nil {
+		return
+	}
 
 	// The name should be followed by an opening parenthesis ('(').
 	if err = p.expectRune('(', contextDesc); err != nil {
@@ -170,6 +230,19 @@ func (p *sourceParser) parseServiceFunction(serviceDecl *declarations.Service) (
 	}
 
 	// Parse arguments.
+	reservations := &reservationContext{
+		list:        &decl.ReservationList,
+		desc:        "argument",
+		contextDesc: "argument reservation",
+		declarationUsing: func(r *declarations.Reservation) interface{} {
+			if argument := decl.ArgumentUsingReservation(r); argument != nil {
+				return argument
+			}
+
+			return nil
+		},
+	}
+
 	for {
 		if err = p.skipNewLines(); err != nil {
 			return
@@ -184,8 +257,39 @@ func (p *sourceParser) parseServiceFunction(serviceDecl *declarations.Service) (
 			return
 		}
 
+		// Annotations precede the argument.
+		if p.tok.Type == token.TokenType('@') {
+			if err = p.parseAnnotations(); err != nil {
+				return
+			}
+		}
+
+		// Annotations must be followed by an argument.
+		if p.tok.Type == token.TokenType(')') || p.atKeyword(reservedKeyword) {
+			if err = p.checkNotAnnotated(); err != nil {
+				return
+			}
+		}
+
+		// Reservations are followed by a comma unless ending the list.
+		if p.atKeyword(reservedKeyword) {
+			var stop bool
+			if stop, err = p.parseReservation(reservations); err != nil {
+				return
+			}
+
+			if !stop && p.tok.Type != token.TokenType(')') {
+				if err = p.expectRune(',', contextDesc); err != nil {
+					return
+				}
+			}
+
+			continue
+		}
+
 		// We should have an unsigned integer constant at this point.
 		var index uint
+		span := tokenSpan(&p.tok)
 
 		switch p.tok.Type {
 		case token.UintConstant:
@@ -195,6 +299,8 @@ func (p *sourceParser) parseServiceFunction(serviceDecl *declarations.Service) (
 				return nil, p.parseErrorHere("argument indexes are 1-based")
 			} else if decl.ArgumentIndexInUse(index) {
 				return nil, p.parseErrorHeref("argument index %d already in use", index)
+			} else if err = p.checkIndexNotReserved(reservations, index, &p.tok); err != nil {
+				return
 			}
 
 		case token.EndOfFile:
@@ -226,8 +332,12 @@ func (p *sourceParser) parseServiceFunction(serviceDecl *declarations.Service) (
 
 			if decl.ArgumentNameInUse(name) {
 				return nil, p.parseErrorHeref("argument named '%s' already declared", name)
+			} else if err = p.checkNameNotReserved(reservations, name, &p.tok); err != nil {
+				return
 			}
 
+			span.end = p.tok.End
+
 		case token.NewLine:
 			return nil, p.parseErrorHeref("unexpected end of line in %s", argumentContextDesc)
 
@@ -244,7 +354,15 @@ func (p *sourceParser) parseServiceFunction(serviceDecl *declarations.Service) (
 
 		// Then a type.
 		var argumentType declarations.Type
-		if argumentType, err = p.parseType(argumentContextDesc, decl.Name); err != nil {
+		if argumentType, err = p.parseType(argumentContextDesc); err != nil {
+			return
+		}
+
+		// Add the argument to the function declaration.
+		argument := decl.AddArgument(index, name, p.documentationParagraphs(), argumentType)
+		p.declarationSpans[argument] = span
+
+		if argument.Annotations, err = p.declarationAnnotations(argumentTarget); err != nil {
 			return
 		}
 
@@ -252,6 +370,15 @@ func (p *sourceParser) parseServiceFunction(serviceDecl *declarations.Service) (
 			return
 		}
 
+		// Optionally followed by a default value.
+		if err = p.parseDefaultValue(argumentType, argumentContextDesc, &argument.Default); err != nil {
+			return
+		}
+
+		if err = p.checkConstraints(argument.Annotations, argumentType, &argument.Default, fmt.Sprintf("argument '%s'", name)); err != nil {
+			return
+		}
+
 		// If this is not the last argument, we should expect a comma here.
 		//
 		// Mostly we require this before the user throws in a new line, so that
@@ -262,9 +389,6 @@ func (p *sourceParser) parseServiceFunction(serviceDecl *declarations.Service) (
 				return
 			}
 		}
-
-		// Add the argument to the function declaration.
-		decl.AddArgument(index, name, argumentType)
 	}
 
 	// The argument list should be followed by a closing parenthesis (')').
@@ -272,15 +396,36 @@ func (p *sourceParser) parseServiceFunction(serviceDecl *declarations.Service) (
 		return
 	}
 
+	var throws bool
+	if throws, err = p.atThrows(); err != nil {
+		return
+	}
+
+	// One-way functions do not return a value.
+	if oneway && p.tok.Type != token.NewLine && p.tok.Type != token.EndOfFile && !throws {
+		return nil, p.parseErrorHere("one-way functions cannot return a value")
+	}
+
 	// Parse the return type.
-	if p.tok.Type != token.NewLine && p.tok.Type != token.EndOfFile {
-		if decl.ReturnType, err = p.parseType(contextDesc, decl.Name); err != nil {
+	if p.tok.Type != token.NewLine && p.tok.Type != token.EndOfFile && !throws {
+		if decl.ReturnType, err = p.parseType(contextDesc); err != nil {
 			return
 		}
 
 		if err = p.next(); err != nil {
 			return
 		}
+
+		if throws, err = p.atThrows(); err != nil {
+			return
+		}
+	}
+
+	// Parse the declared exceptions.
+	if throws {
+		if err = p.parseThrows(decl); err != nil {
+			return
+		}
 	}
 
 	// The function declaration should be followed by a new line.
@@ -295,6 +440,19 @@ func (p *sourceParser) parseServiceFunction(serviceDecl *declarations.Service) (
 		return nil, p.parseErrorHeref("expected new line after %s", contextDesc)
 	}
 
+	// Make sure that a redeclared inherited function keeps its signature.
+	if inheritedDecl != nil {
+		if err = p.whenResolved(func() error {
+			if !decl.SameSignature(inheritedDecl) {
+				return p.parseErrorForToken(fmt.Sprintf("function '%s' redeclares a function inherited from '%s' with a different signature", name, serviceDecl.ParentName), &nameTok)
+			}
+
+			return nil
+		}, append(signatureTypes(decl), signatureTypes(inheritedDecl)...)...); err != nil {
+			return nil, err
+		}
+	}
+
 	err = p.next()
 	return
 }
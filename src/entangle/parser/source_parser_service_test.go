@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestServiceInheritance(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"inherit", "service Base {\n    Ping()\n}\n\nservice Derived : Base {\n    Get() string\n}\n", ""},
+		{"inherit empty", "service Base {\n    Ping()\n}\n\nservice Derived : Base {\n}\n", ""},
+		{"inherit transitively", "service Base {\n    Ping()\n}\n\nservice Middle : Base {\n}\n\nservice Derived : Middle {\n    Ping()\n}\n", ""},
+		{"redeclare", "service Base {\n    Get(1: id uint64) string\n}\n\nservice Derived : Base {\n    Get(1: id uint64) string\n}\n", ""},
+		{"unknown parent", "service Derived : Base {\n}\n", "unknown parent service 'Base'"},
+		{"missing parent", "service Derived : {\n}\n", "expected parent service name"},
+		{"redeclare twice", "service Base {\n    Ping()\n}\n\nservice Derived : Base {\n    Ping()\n    Ping()\n}\n", "function name 'Ping' has already been declared"},
+		{"redeclare with other result", "service Base {\n    Get(1: id uint64) string\n}\n\nservice Derived : Base {\n    Get(1: id uint64) uint64\n}\n", "function 'Get' redeclares a function inherited from 'Base' with a different signature"},
+		{"redeclare with other arguments", "service Base {\n    Get(1: id uint64) string\n}\n\nservice Derived : Base {\n    Get(1: id string) string\n}\n", "function 'Get' redeclares a function inherited from 'Base' with a different signature"},
+		{"redeclare with annotations", "service Base {\n    @timeout(5s)\n    @idempotent\n    Get(@sensitive 1: id uint64) string\n}\n\nservice Derived : Base {\n    @timeout(5s)\n    @idempotent\n    Get(@sensitive 1: id uint64) string\n}\n", ""},
+		{"redeclare with other annotations", "service Base {\n    Get(1: id uint64) string\n}\n\nservice Derived : Base {\n    @timeout(5s)\n    Get(1: id uint64) string\n}\n", "function 'Get' redeclares a function inherited from 'Base' with a different signature"},
+		{"redeclare with other annotation arguments", "service Base {\n    @timeout(5s)\n    Get(1: id uint64) string\n}\n\nservice Derived : Base {\n    @timeout(10s)\n    Get(1: id uint64) string\n}\n", "function 'Get' redeclares a function inherited from 'Base' with a different signature"},
+		{"redeclare deprecated", "service Base {\n    Get(1: id uint64) string\n}\n\nservice Derived : Base {\n    @deprecated\n    Get(1: id uint64) string\n}\n", "function 'Get' redeclares a function inherited from 'Base' with a different signature"},
+		{"redeclare with other argument annotations", "service Base {\n    Get(1: id uint64) string\n}\n\nservice Derived : Base {\n    Get(@sensitive 1: id uint64) string\n}\n", "function 'Get' redeclares a function inherited from 'Base' with a different signature"},
+		{"redeclare with default", "service Base {\n    List(1: limit uint32 = 10) string\n}\n\nservice Derived : Base {\n    List(1: limit uint32 = 10) string\n}\n", ""},
+		{"redeclare with other default", "service Base {\n    List(1: limit uint32 = 10) string\n}\n\nservice Derived : Base {\n    List(1: limit uint32 = 20) string\n}\n", "function 'List' redeclares a function inherited from 'Base' with a different signature"},
+		{"redeclare without default", "service Base {\n    List(1: limit uint32 = 10) string\n}\n\nservice Derived : Base {\n    List(1: limit uint32) string\n}\n", "function 'List' redeclares a function inherited from 'Base' with a different signature"},
+		{"duplicate function", "service Base {\n    Ping()\n    Ping()\n}\n", "function name 'Ping' has already been declared"},
+	})
+}
+
+func TestServiceInheritedFunctions(t *testing.T) {
+	decl := mustParseTestSource(t, `service Base {
+    Ping()
+    Get(1: id uint64) string
+}
+
+service Middle : Base {
+    Get(1: id uint64) string
+    Count() uint32
+}
+
+service Derived : Middle {
+    Delete(1: id uint64)
+}
+`)
+
+	derived := decl.Services["Derived"]
+	if derived.Parent != decl.Services["Middle"] {
+		t.Fatalf("expected parent Middle of Derived, got %v", derived.Parent)
+	}
+
+	for _, name := range []string{"Ping", "Get", "Count", "Delete"} {
+		if !derived.FunctionNameInUse(name) {
+			t.Errorf("expected function %s in Derived", name)
+		}
+	}
+
+	declared := derived.DeclaredFunctionsSortedByName()
+	if len(declared) != 1 || declared[0].Name != "Delete" {
+		t.Errorf("expected only Delete to be declared by Derived, got %d functions", len(declared))
+	}
+
+	if functions := decl.Services["Middle"].DeclaredFunctionsSortedByName(); len(functions) != 1 || functions[0].Name != "Count" {
+		t.Errorf("expected only Count to be declared by Middle, got %d functions", len(functions))
+	}
+}
+
+func TestArgumentDocumentation(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"documented", "service S {\n    Get(\n        // The identifier.\n        1: id uint64,\n    ) string\n}\n", ""},
+		{"partially documented", "service S {\n    Get(\n        // The identifier.\n        1: id uint64,\n        2: name string,\n    ) string\n}\n", ""},
+		{"single line", "service S {\n    Get(1: id uint64, 2: name string) string\n}\n", ""},
+		{"dangling", "service S {\n    Get(1: id uint64,\n        // Dangling.\n    ) string\n}\n", "expected argument index in service function definition"},
+	})
+
+	decl := mustParseTestSource(t, `service S {
+    // Get a record.
+    Get(
+        // The identifier.
+        //
+        // Identifiers are never reused.
+        1: id uint64,
+        2: name string,
+    ) string
+}
+`)
+
+	function := decl.Services["S"].Functions[0]
+	if expected := []string{"Get a record."}; !reflect.DeepEqual(function.Documentation, expected) {
+		t.Errorf("expected documentation %q of Get, got %q", expected, function.Documentation)
+	}
+
+	if expected := []string{"The identifier.", "Identifiers are never reused."}; !reflect.DeepEqual(function.Arguments[0].Documentation, expected) {
+		t.Errorf("expected documentation %q of argument id, got %q", expected, function.Arguments[0].Documentation)
+	}
+
+	if len(function.Arguments[1].Documentation) != 0 {
+		t.Errorf("expected no documentation of argument name, got %q", function.Arguments[1].Documentation)
+	}
+}
+
+func TestOnewayFunctions(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"oneway", "service Log {\n    oneway Record(1: message string)\n}\n", ""},
+		{"oneway without arguments", "service Log {\n    oneway Flush()\n}\n", ""},
+		{"annotated", "service Log {\n    @timeout(1s)\n    oneway Record(1: message string)\n}\n", ""},
+		{"contextual keyword arguments", "service Log {\n    oneway Record(1: timestamp int64, 2: oneway bool, 3: flags uint32, 4: set bool, 5: stream string)\n}\n", ""},
+		{"returning", "service Log {\n    oneway Record(1: message string) string\n}\n", "one-way functions cannot return a value"},
+		{"missing name", "service Log {\n    oneway (1: message string)\n}\n", "'oneway' is not a valid function name. Function names must be upper camel case"},
+		{"redeclared as oneway", "service Base {\n    Record(1: message string)\n}\n\nservice Log : Base {\n    oneway Record(1: message string)\n}\n", "function 'Record' redeclares a function inherited from 'Base' with a different signature"},
+	})
+}
+
+func TestOnewayDeclaration(t *testing.T) {
+	decl := mustParseTestSource(t, `service Log {
+    oneway Record(1: oneway bool)
+    Flush()
+}
+`)
+
+	functions := decl.Services["Log"].Functions
+	if !functions[0].Oneway || functions[0].Name != "Record" {
+		t.Errorf("expected Record to be one-way")
+	}
+
+	if functions[0].Arguments[0].Name != "oneway" {
+		t.Errorf("expected argument oneway of Record, got %s", functions[0].Arguments[0].Name)
+	}
+
+	if functions[1].Oneway {
+		t.Errorf("expected Flush not to be one-way")
+	}
+}
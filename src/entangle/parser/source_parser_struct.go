@@ -3,6 +3,7 @@ package parser
 import (
 	"entangle/declarations"
 	"entangle/token"
+	"fmt"
 )
 
 // Parse a struct declaration.
@@ -36,6 +37,8 @@ func (p *sourceParser) parseStruct() (err error) {
 			return p.parseErrorHeref("struct name '%s' would override previous type declaration", name)
 		}
 
+		p.typeDeclarationTokens = append(p.typeDeclarationTokens, p.tok)
+
 	default:
 		return p.parseErrorHere("expected struct name")
 	}
@@ -77,6 +80,25 @@ func (p *sourceParser) parseStruct() (err error) {
 		return
 	}
 
+	// Make sure polymorphism is declared by the root of the hierarchy, and
+	// that patches, which are serialized as maps, are not polymorphic.
+	var annotations declarations.Annotations
+	if annotations, err = p.declarationAnnotations(structTarget); err != nil {
+		return
+	}
+
+	if polymorphic := annotations.Annotation(declarations.PolymorphicAnnotation); polymorphic != nil && parentDecl != nil {
+		span := p.declarationSpans[polymorphic]
+		return p.parseError(fmt.Sprintf("only the root of a struct hierarchy can be polymorphic, not an inheritor of '%s'", parentDecl.Name), span.start, span.end)
+	}
+
+	if patch := annotations.Annotation(declarations.PatchAnnotation); patch != nil {
+		if annotations.Has(declarations.PolymorphicAnnotation) || (parentDecl != nil && parentDecl.Polymorphic()) {
+			span := p.declarationSpans[patch]
+			return p.parseError("patches cannot be polymorphic", span.start, span.end)
+		}
+	}
+
 	// Let's initialize the declaration at this point.
 	var decl *declarations.Struct
 	documentation := p.documentationParagraphs()
@@ -87,104 +109,12 @@ func (p *sourceParser) parseStruct() (err error) {
 		decl = declarations.NewStruct(name, documentation)
 	}
 
+	decl.Annotations = annotations
+
 	// From here on out, we should be getting documentation and field
 	// definitions.
-	for {
-		if err = p.skipNewLinesStoreDocumentation(); err != nil {
-			return
-		}
-
-		// If we've reached a '}' here, let's break out of the loop.
-		if p.tok.Type == token.TokenType('}') {
-			break
-		}
-
-		// We should have an unsigned integer constant at this point.
-		var fieldIndex uint
-
-		switch p.tok.Type {
-		case token.UintConstant:
-			fieldIndex = uint(p.tok.UintValue)
-
-			if fieldIndex == 0 {
-				return p.parseErrorHere("field indexes are 1-based")
-			} else if decl.FieldIndexInUse(fieldIndex) {
-				return p.parseErrorHeref("field index %d already in use", fieldIndex)
-			}
-
-		case token.EndOfFile:
-			return p.parseErrorHere("unexpected end of file in struct declaration")
-
-		default:
-			return p.parseErrorHere("expected field index")
-		}
-
-		if err = p.next(); err != nil {
-			return
-		}
-
-		// The unsigned integer should be followed by a colon (':').
-		if err = p.expectRune(':', fieldContextDesc); err != nil {
-			return
-		}
-
-		// We should then get a name.
-		var name string
-
-		switch p.tok.Type {
-		case token.Identifier:
-			if err = p.validateFieldName(&p.tok); err != nil {
-				return
-			}
-
-			name = p.tok.StringValue
-
-			if decl.FieldNameInUse(name) {
-				return p.parseErrorHeref("field name '%s' already in use", name)
-			}
-
-		case token.NewLine:
-			return p.parseErrorHere("unexpected end of line in struct field declaration")
-
-		case token.EndOfFile:
-			return p.parseErrorHere("unexpected end of file in struct field declaration")
-
-		default:
-			return p.parseErrorHere("expected field name in struct field definition")
-		}
-
-		if err = p.next(); err != nil {
-			return
-		}
-
-		// Then a type.
-		var fieldType declarations.Type
-		if fieldType, err = p.parseType(fieldContextDesc, decl.Name); err != nil {
-			return
-		}
-
-		if err = p.next(); err != nil {
-			return
-		}
-
-		// And then a new line.
-		switch p.tok.Type {
-		case token.NewLine:
-			break
-
-		case token.EndOfFile:
-			return p.parseErrorHere("unexpected end of file in struct declaration")
-
-		default:
-			return p.parseErrorHere("expected new line after struct field definition")
-		}
-
-		// Add the field to the struct declaration.
-		decl.AddField(fieldIndex, name, p.documentationParagraphs(), fieldType)
-
-		if err = p.next(); err != nil {
-			return
-		}
+	if err = p.parseFields(&decl.FieldList, contextDesc, fieldContextDesc, true); err != nil {
+		return
 	}
 
 	// Here, we should be met with a closing curly brace and a new line or
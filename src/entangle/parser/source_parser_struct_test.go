@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPatches(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"patch", "@patch\nstruct UserPatch {\n    1: Name string\n    2: Email *string\n    3: Nick string = \"x\"\n}\n", ""},
+		{"empty", "@patch\nstruct Empty {\n}\n", ""},
+		{"inheritor", "struct Base {\n    1: Name string\n}\n\n@patch\nstruct Patch : Base {\n    2: Email *string\n}\n", ""},
+		{"argument", "@patch\nstruct UserPatch {\n    1: Name string\n}\n\nservice Users {\n    Update(1: patch UserPatch) string\n}\n", ""},
+		{"with arguments", "@patch(true)\nstruct UserPatch {\n    1: Name string\n}\n", "annotation '@patch' takes no arguments"},
+		{"polymorphic", "@patch\n@polymorphic\nstruct UserPatch {\n    1: Name string\n}\n", "patches cannot be polymorphic"},
+		{"polymorphic parent", "@polymorphic\nstruct Base {\n    1: Name string\n}\n\n@patch\nstruct Patch : Base {\n    2: Email *string\n}\n", "patches cannot be polymorphic"},
+	})
+}
+
+func TestPatchDeclaration(t *testing.T) {
+	decl := mustParseTestSource(t, `struct User {
+    1: Name string
+}
+
+@patch
+struct UserPatch {
+    1: Name string
+}
+`)
+
+	if decl.Structs["User"].Patch() {
+		t.Errorf("expected User not to be a patch")
+	}
+
+	if !decl.Structs["UserPatch"].Patch() {
+		t.Errorf("expected UserPatch to be a patch")
+	}
+}
+
+func TestPreservedUnknownElements(t *testing.T) {
+	decl := mustParseTestSource(t, `struct Base {
+    1: Name string
+}
+
+struct Record : Base {
+    2: Email string
+}
+
+struct Empty {
+}
+
+@patch
+struct Patch : Base {
+    2: Email *string
+}
+`)
+
+	for name, expected := range map[string]bool{
+		"Base":   true,
+		"Record": true,
+		"Empty":  true,
+		"Patch":  false,
+	} {
+		if actual := decl.Structs[name].PreservesUnknown(); actual != expected {
+			t.Errorf("expected %s preserving unknown elements to be %v, not %v", name, expected, actual)
+		}
+	}
+}
+
+func TestPolymorphicStructs(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"root", "@polymorphic\nstruct Shape {\n    1: Name string\n}\n", ""},
+		{"hierarchy", "@polymorphic\nstruct Shape {\n    1: Name string\n}\n\nstruct Circle : Shape {\n    2: Radius float64\n}\n\nstruct Rectangle : Shape {\n    2: Width float64\n}\n\nstruct Square : Rectangle {\n}\n", ""},
+		{"field", "@polymorphic\nstruct Shape {\n    1: Name string\n}\n\nstruct Drawing {\n    1: Shapes []Shape\n    2: Main *Shape\n}\n", ""},
+		{"with arguments", "@polymorphic(true)\nstruct Shape {\n    1: Name string\n}\n", "annotation '@polymorphic' takes no arguments"},
+		{"inheritor", "struct Shape {\n    1: Name string\n}\n\n@polymorphic\nstruct Circle : Shape {\n    2: Radius float64\n}\n", "only the root of a struct hierarchy can be polymorphic, not an inheritor of 'Shape'"},
+		{"inheritor of polymorphic", "@polymorphic\nstruct Shape {\n    1: Name string\n}\n\n@polymorphic\nstruct Circle : Shape {\n    2: Radius float64\n}\n", "only the root of a struct hierarchy can be polymorphic, not an inheritor of 'Shape'"},
+		{"unknown parent", "struct Circle : Shape {\n    2: Radius float64\n}\n", "unknown parent struct 'Shape'"},
+	})
+}
+
+func TestPolymorphicHierarchy(t *testing.T) {
+	decl := mustParseTestSource(t, `@polymorphic
+struct Shape {
+    1: Name string
+}
+
+struct Circle : Shape {
+    2: Radius float64
+}
+
+struct Rectangle : Shape {
+    2: Width float64
+}
+
+struct Square : Rectangle {
+}
+
+struct Plain {
+    1: Name string
+}
+`)
+
+	for name, expected := range map[string]bool{
+		"Shape":     true,
+		"Circle":    true,
+		"Rectangle": true,
+		"Square":    true,
+		"Plain":     false,
+	} {
+		if actual := decl.Structs[name].Polymorphic(); actual != expected {
+			t.Errorf("expected %s being polymorphic to be %v, not %v", name, expected, actual)
+		}
+	}
+
+	var descendants []string
+	for _, s := range decl.Structs["Shape"].Descendants() {
+		descendants = append(descendants, s.Name)
+	}
+
+	if expected := []string{"Circle", "Rectangle", "Square"}; !reflect.DeepEqual(descendants, expected) {
+		t.Errorf("expected descendants of Shape to be %v, not %v", expected, descendants)
+	}
+
+	var ancestors []string
+	for _, s := range decl.Structs["Square"].Ancestors() {
+		ancestors = append(ancestors, s.Name)
+	}
+
+	if expected := []string{"Rectangle", "Shape"}; !reflect.DeepEqual(ancestors, expected) {
+		t.Errorf("expected ancestors of Square to be %v, not %v", expected, ancestors)
+	}
+
+	if fields := decl.Structs["Square"].FieldsSortedByIndex(); len(fields) != 2 {
+		t.Errorf("expected Square to inherit 2 fields, not %d", len(fields))
+	}
+}
@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"entangle/errors"
+	"entangle/source"
+	"testing"
+)
+
+// Parse test case.
+type parseTestCase struct {
+	// Name.
+	Name string
+
+	// Source following the definition name.
+	Source string
+
+	// Description of the expected error.
+	//
+	// Empty if the source is expected to parse.
+	Error string
+}
+
+// Parse the source of a definition file named test.
+func parseTestSource(t *testing.T, data string) (*declarations.Interface, error) {
+	src, err := source.FromString("definition test\n\n"+data, "test.etg")
+	if err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+
+	return Parse(src)
+}
+
+// Parse the source of a definition file named test, failing on errors.
+func mustParseTestSource(t *testing.T, data string) *declarations.Interface {
+	decl, err := parseTestSource(t, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return decl
+}
+
+// Run parse test cases.
+//
+// Only the first error is compared for sources expected not to parse.
+func runParseTestCases(t *testing.T, testCases []parseTestCase) {
+	for _, testCase := range testCases {
+		_, err := parseTestSource(t, testCase.Source)
+
+		if testCase.Error == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", testCase.Name, err)
+			}
+			continue
+		}
+
+		parseErr, ok := err.(errors.ParseError)
+		if !ok {
+			t.Errorf("%s: expected error '%s', got %v", testCase.Name, testCase.Error, err)
+			continue
+		}
+
+		if parseErr.Description() != testCase.Error {
+			t.Errorf("%s: expected error '%s', got '%s'", testCase.Name, testCase.Error, parseErr.Description())
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"entangle/token"
+)
+
+// Determine if the current token starts the exceptions declared to be raised
+// by a function.
+//
+// The throws keyword is contextual, so a following '.' makes it the name of an
+// import qualifying the return type instead.
+func (p *sourceParser) atThrows() (throws bool, err error) {
+	if !p.atKeyword(throwsKeyword) {
+		return
+	}
+
+	var following token.Token
+	if following, err = p.peek(); err != nil {
+		return
+	}
+
+	return following.Type != token.TokenType('.'), nil
+}
+
+// Parse the exceptions declared to be raised by a function.
+//
+// Invoked with the throws keyword as the current token. Returns with the token
+// following the closing parenthesis as the current token.
+func (p *sourceParser) parseThrows(decl *declarations.Function) (err error) {
+	contextDesc := "throws declaration"
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// The keyword should be followed by an opening parenthesis ('(').
+	if err = p.expectRune('(', contextDesc); err != nil {
+		return
+	}
+
+	for {
+		if err = p.skipNewLines(); err != nil {
+			return
+		}
+
+		if p.tok.Type == token.TokenType(')') {
+			if len(decl.Throws) == 0 {
+				return p.parseErrorHeref("expected exception name in %s", contextDesc)
+			}
+
+			break
+		}
+
+		// Parse the exception name.
+		var thrown *declarations.ThrownException
+
+		switch p.tok.Type {
+		case token.Identifier:
+			if thrown, err = p.parseThrownException(); err != nil {
+				return
+			}
+
+		case token.EndOfFile:
+			return p.parseErrorHeref("unexpected end of file in %s", contextDesc)
+
+		default:
+			return p.parseErrorHeref("expected exception name in %s", contextDesc)
+		}
+
+		if decl.ThrowsException(thrown.Exception) {
+			return p.parseErrorHeref("exception '%s' already declared", thrown.Exception.Name)
+		}
+
+		decl.Throws = append(decl.Throws, thrown)
+
+		if err = p.next(); err != nil {
+			return
+		}
+
+		// If this is not the last exception, we should expect a comma here.
+		if p.tok.Type != token.TokenType(')') {
+			if err = p.expectRune(',', contextDesc); err != nil {
+				return
+			}
+		}
+	}
+
+	// The exception list should be followed by a closing parenthesis (')').
+	return p.expectRune(')', contextDesc)
+}
+
+// Parse a reference to an exception.
+//
+// Invoked with the exception or import name as the current token. Returns
+// with the last token of the reference as the current token.
+func (p *sourceParser) parseThrownException() (thrown *declarations.ThrownException, err error) {
+	// Exceptions declared in imported interfaces are referenced by the import
+	// name followed by a '.' and the exception name.
+	if importDecl, ok := p.decl.Imports[p.tok.StringValue]; ok {
+		if err = p.next(); err != nil {
+			return
+		}
+
+		if p.tok.Type != token.TokenType('.') {
+			return nil, p.parseErrorHere("expected '.' following import name")
+		}
+
+		if err = p.next(); err != nil {
+			return
+		}
+
+		if p.tok.Type != token.Identifier {
+			return nil, p.parseErrorHeref("expected exception name from import '%s'", importDecl.Name)
+		}
+
+		excDecl, found := importDecl.Interface.Exceptions[p.tok.StringValue]
+		if !found {
+			return nil, p.parseErrorHeref("unknown exception '%s' in import '%s'", p.tok.StringValue, importDecl.Name)
+		}
+
+		return &declarations.ThrownException{
+			Exception: excDecl,
+			Import:    importDecl,
+		}, nil
+	}
+
+	excDecl, found := p.decl.Exceptions[p.tok.StringValue]
+	if !found {
+		return nil, p.parseErrorHeref("unknown exception '%s'", p.tok.StringValue)
+	}
+
+	return &declarations.ThrownException{
+		Exception: excDecl,
+	}, nil
+}
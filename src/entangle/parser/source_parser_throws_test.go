@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"entangle/errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestThrows(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"single", "exception NotFound\n\nservice Users {\n    Get(1: id uint64) string throws (NotFound)\n}\n", ""},
+		{"multiple", "exception NotFound\nexception Denied\n\nservice Users {\n    Get(1: id uint64) string throws (NotFound, Denied)\n}\n", ""},
+		{"without return type", "exception NotFound\n\nservice Users {\n    Delete(1: id uint64) throws (NotFound)\n}\n", ""},
+		{"multiple lines", "exception NotFound\nexception Denied\n\nservice Users {\n    Get(1: id uint64) string throws (NotFound,\n        Denied)\n}\n", ""},
+		{"argument named throws", "exception NotFound\n\nservice Users {\n    Get(1: throws bool) string throws (NotFound)\n}\n", ""},
+		{"unknown exception", "service Users {\n    Get(1: id uint64) string throws (NotFound)\n}\n", "unknown exception 'NotFound'"},
+		{"struct", "struct NotFound {\n    1: ID uint64\n}\n\nservice Users {\n    Get(1: id uint64) string throws (NotFound)\n}\n", "unknown exception 'NotFound'"},
+		{"unknown import", "service Users {\n    Get(1: id uint64) string throws (errors.NotFound)\n}\n", "unknown exception 'errors'"},
+		{"duplicate", "exception NotFound\n\nservice Users {\n    Get(1: id uint64) string throws (NotFound, NotFound)\n}\n", "exception 'NotFound' already declared"},
+		{"empty", "exception NotFound\n\nservice Users {\n    Get(1: id uint64) string throws ()\n}\n", "expected exception name in throws declaration"},
+		{"missing parenthesis", "exception NotFound\n\nservice Users {\n    Get(1: id uint64) string throws NotFound\n}\n", "expected '(' in throws declaration"},
+	})
+}
+
+func TestThrowsDeclaration(t *testing.T) {
+	decl := mustParseTestSource(t, `exception NotFound
+exception Denied
+
+service Users {
+    Get(1: id uint64) string throws (Denied, NotFound)
+}
+`)
+
+	get, _ := decl.Services["Users"].Function("Get")
+	if len(get.Throws) != 2 || get.Throws[0].Exception != decl.Exceptions["Denied"] || get.Throws[1].Exception != decl.Exceptions["NotFound"] {
+		t.Fatalf("expected Get to throw Denied and NotFound, got %v", get.Throws)
+	}
+
+	if get.Throws[0].Import != nil || get.Throws[1].Import != nil {
+		t.Errorf("expected exceptions of Get to be local")
+	}
+
+	if get.ReturnType == nil {
+		t.Errorf("expected Get to return a value")
+	}
+}
+
+func TestImportedThrows(t *testing.T) {
+	dir := writeDefinitionFiles(t, map[string]string{
+		"main.etg": `definition main
+import "errors.etg"
+
+exception NotFound
+
+struct User {
+    1: ID uint64
+}
+
+service Users {
+    Get(1: id uint64) errors.User throws (NotFound, errors.RateLimited)
+    List() []User throws (errors.RateLimited)
+}
+`,
+		"errors.etg": `definition errors
+
+exception RateLimited {
+    1: RetryAfter uint32
+}
+
+struct User {
+    1: ID uint64
+}
+`,
+		"unknown.etg": `definition unknown
+import "errors.etg"
+
+service Users {
+    Get(1: id uint64) throws (errors.NotFound)
+}
+`,
+		"duplicate.etg": `definition duplicate
+import "errors.etg"
+
+service Users {
+    Get(1: id uint64) throws (errors.RateLimited, errors.RateLimited)
+}
+`,
+		"struct.etg": `definition structs
+import "errors.etg"
+
+service Users {
+    Get(1: id uint64) throws (errors.User)
+}
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	decl, err := parseDefinitionFile(t, filepath.Join(dir, "main.etg"), &Options{})
+	if err != nil {
+		t.Fatalf("unexpected error parsing imported exceptions: %v", err)
+	}
+
+	errorsImport := decl.Imports["errors"]
+	rateLimited := errorsImport.Interface.Exceptions["RateLimited"]
+
+	get, _ := decl.Services["Users"].Function("Get")
+	if len(get.Throws) != 2 || get.Throws[1].Exception != rateLimited || get.Throws[1].Import != errorsImport {
+		t.Errorf("expected Get to throw errors.RateLimited")
+	}
+
+	if !get.ThrowsException(decl.Exceptions["NotFound"]) || get.Throws[0].Import != nil {
+		t.Errorf("expected Get to throw the local NotFound")
+	}
+
+	list, _ := decl.Services["Users"].Function("List")
+	if len(list.Throws) != 1 || list.Throws[0].Exception != rateLimited {
+		t.Errorf("expected List to throw errors.RateLimited")
+	}
+
+	for _, testCase := range []struct {
+		Path        string
+		Description string
+	}{
+		{"unknown.etg", "unknown exception 'NotFound' in import 'errors'"},
+		{"duplicate.etg", "exception 'RateLimited' already declared"},
+		{"struct.etg", "unknown exception 'User' in import 'errors'"},
+	} {
+		_, err := parseDefinitionFile(t, filepath.Join(dir, testCase.Path), &Options{})
+
+		parseErr, ok := err.(errors.ParseError)
+		if !ok {
+			t.Errorf("expected parse error for %s, got %v", testCase.Path, err)
+			continue
+		}
+
+		if parseErr.Description() != testCase.Description {
+			t.Errorf("expected error '%s' for %s, got '%s'", testCase.Description, testCase.Path, parseErr.Description())
+		}
+	}
+}
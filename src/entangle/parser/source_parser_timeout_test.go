@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeouts(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"seconds", "service Users {\n    @timeout(5s)\n    Get(1: id int64) string\n}\n", ""},
+		{"compound", "service Users {\n    @timeout(1m30s)\n    Get(1: id int64) string\n}\n", ""},
+		{"one-way", "service Users {\n    @timeout(250ms)\n    oneway Touch(1: id int64)\n}\n", ""},
+		{"zero", "service Users {\n    @timeout(0s)\n    Get(1: id int64) string\n}\n", "annotation '@timeout' takes a positive duration as its only argument"},
+		{"negative", "service Users {\n    @timeout(-5s)\n    Get(1: id int64) string\n}\n", "annotation '@timeout' takes a positive duration as its only argument"},
+		{"without argument", "service Users {\n    @timeout\n    Get(1: id int64) string\n}\n", "annotation '@timeout' takes a positive duration as its only argument"},
+		{"integer", "service Users {\n    @timeout(5)\n    Get(1: id int64) string\n}\n", "annotation '@timeout' takes a positive duration as its only argument"},
+		{"keyed", "service Users {\n    @timeout(after = 5s)\n    Get(1: id int64) string\n}\n", "annotation '@timeout' takes a positive duration as its only argument"},
+		{"multiple durations", "service Users {\n    @timeout(5s, 10s)\n    Get(1: id int64) string\n}\n", "annotation '@timeout' takes a positive duration as its only argument"},
+		{"on service", "@timeout(5s)\nservice Users {\n    Get(1: id int64) string\n}\n", "annotation '@timeout' is only allowed on functions"},
+		{"on argument", "service Users {\n    Get(@timeout(5s) 1: id int64) string\n}\n", "annotation '@timeout' is only allowed on functions"},
+	})
+}
+
+func TestIdempotentFunctions(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"idempotent", "service Users {\n    @idempotent\n    Get(1: id int64) string\n}\n", ""},
+		{"with timeout", "service Users {\n    @idempotent\n    @timeout(5s)\n    Get(1: id int64) string\n}\n", ""},
+		{"with arguments", "service Users {\n    @idempotent(true)\n    Get(1: id int64) string\n}\n", "annotation '@idempotent' takes no arguments"},
+		{"on struct", "@idempotent\nstruct User {\n    1: ID int64\n}\n", "annotation '@idempotent' is only allowed on functions"},
+	})
+}
+
+func TestTimeoutDeclarations(t *testing.T) {
+	decl := mustParseTestSource(t, `service Users {
+    @timeout(1m30s)
+    @idempotent
+    Get(1: id int64) string
+
+    Put(1: id int64, 2: name string)
+}
+
+service Admins : Users {
+    @timeout(250ms)
+    Delete(1: id int64)
+}
+`)
+
+	for _, c := range []struct {
+		service, function string
+		timeout           time.Duration
+		idempotent        bool
+	}{
+		{"Users", "Get", 90 * time.Second, true},
+		{"Users", "Put", 0, false},
+		{"Admins", "Get", 90 * time.Second, true},
+		{"Admins", "Delete", 250 * time.Millisecond, false},
+	} {
+		fun, found := decl.Services[c.service].Function(c.function)
+		if !found {
+			t.Errorf("expected %s to have a function %s", c.service, c.function)
+			continue
+		}
+
+		if actual := fun.Timeout(); actual != c.timeout {
+			t.Errorf("expected timeout of %s.%s to be %v, not %v", c.service, c.function, c.timeout, actual)
+		}
+
+		if actual := fun.Idempotent(); actual != c.idempotent {
+			t.Errorf("expected %s.%s being idempotent to be %v, not %v", c.service, c.function, c.idempotent, actual)
+		}
+	}
+}
@@ -7,7 +7,10 @@ import (
 )
 
 // Parse a type.
-func (p *sourceParser) parseType(declarationDesc, self string) (decl declarations.Type, err error) {
+//
+// References to types not yet declared in the source are resolved once the
+// whole source has been parsed.
+func (p *sourceParser) parseType(declarationDesc string) (decl declarations.Type, err error) {
 	// First, check if we're htting a '*' indicating that the type is nilable.
 	nilable := false
 
@@ -22,15 +25,43 @@ func (p *sourceParser) parseType(declarationDesc, self string) (decl declaration
 	// Parse the type itself.
 	switch p.tok.Type {
 	case token.Identifier:
-		// The identifier will refer either to an enum or a struct.
-		if p.tok.StringValue == self && !nilable {
-			err = p.parseErrorHere("non-nilable self references are not allowed")
-		} else if structDecl, ok := p.decl.Structs[p.tok.StringValue]; ok {
-			decl = declarations.NewStructType(structDecl, nilable)
-		} else if enumDecl, ok := p.decl.Enums[p.tok.StringValue]; ok {
-			decl = declarations.NewEnumType(enumDecl, nilable)
-		} else {
-			err = p.parseErrorHere(fmt.Sprintf("unknown type '%s'", p.tok.StringValue))
+		// If the identifier is an import name, the type is declared in the
+		// imported interface.
+		if importDecl, ok := p.decl.Imports[p.tok.StringValue]; ok {
+			return p.parseImportedType(importDecl, declarationDesc, nilable)
+		}
+
+		// Sets, timestamps and durations are named by contextual keywords.
+		switch p.tok.StringValue {
+		case setKeyword:
+			return p.parseSetType(declarationDesc, nilable)
+
+		case timestampKeyword:
+			if nilable {
+				return declarations.NilableTimestampType, nil
+			}
+
+			return declarations.TimestampType, nil
+
+		case durationKeyword:
+			if nilable {
+				return declarations.NilableDurationType, nil
+			}
+
+			return declarations.DurationType, nil
+		}
+
+		// The identifier will refer either to an enum, a struct, a union or a
+		// type alias, which may be declared later in the source.
+		var found bool
+		if decl, found = p.lookupType(p.tok.StringValue, nilable); !found {
+			unresolved := &unresolvedType{
+				tok:     p.tok,
+				nilable: nilable,
+			}
+
+			p.unresolvedTypes = append(p.unresolvedTypes, unresolved)
+			decl = unresolved
 		}
 
 	case token.Map:
@@ -64,22 +95,11 @@ func (p *sourceParser) parseType(declarationDesc, self string) (decl declaration
 		}
 
 		// Parse the key type and make sure it's not a disallowed type.
-		if keyType, err = p.parseType(declarationDesc, ""); err != nil {
+		if keyType, err = p.parseType(declarationDesc); err != nil {
 			return
 		}
 
-		switch keyType.Class() {
-		case declarations.StructClass:
-			err = p.parseErrorHere("structs are not allowed as map keys")
-
-		case declarations.MapClass:
-			err = p.parseErrorHere("maps are not allowed as map keys")
-
-		case declarations.ListClass:
-			err = p.parseErrorHere("arrays are not allowed as map keys")
-		}
-
-		if err != nil {
+		if err = p.checkKeyType(keyType, p.tok, "map keys"); err != nil {
 			return
 		}
 
@@ -111,7 +131,7 @@ func (p *sourceParser) parseType(declarationDesc, self string) (decl declaration
 		}
 
 		// Parse the value type.
-		if valueType, err = p.parseType(declarationDesc, ""); err != nil {
+		if valueType, err = p.parseType(declarationDesc); err != nil {
 			return
 		}
 
@@ -148,7 +168,7 @@ func (p *sourceParser) parseType(declarationDesc, self string) (decl declaration
 
 		// Parse the element type.
 		var elementType declarations.Type
-		if elementType, err = p.parseType(declarationDesc, ""); err != nil {
+		if elementType, err = p.parseType(declarationDesc); err != nil {
 			return
 		}
 
@@ -258,3 +278,160 @@ func (p *sourceParser) parseType(declarationDesc, self string) (decl declaration
 
 	return
 }
+
+// Parse a set type.
+//
+// Invoked with the set keyword as the current token.
+func (p *sourceParser) parseSetType(declarationDesc string, nilable bool) (decl declarations.Type, err error) {
+	// Make sure the next token is a matching '['.
+	if err = p.next(); err != nil {
+		return
+	}
+
+	switch p.tok.Type {
+	case token.TokenType('['):
+		break
+
+	case token.NewLine:
+		err = p.parseErrorHere(fmt.Sprintf("unexpected end of line in %s", declarationDesc))
+
+	case token.EndOfFile:
+		err = p.parseErrorHere(fmt.Sprintf("unexpected end of file in %s", declarationDesc))
+
+	default:
+		err = p.parseErrorHere("expected '['")
+	}
+
+	if err != nil {
+		return
+	}
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// Parse the element type and make sure it's not a disallowed type.
+	var elementType declarations.Type
+	if elementType, err = p.parseType(declarationDesc); err != nil {
+		return
+	}
+
+	if elementType.Nilable() {
+		return nil, p.parseErrorHere("nilable types are not allowed as set elements")
+	}
+
+	if err = p.checkKeyType(elementType, p.tok, "set elements"); err != nil {
+		return
+	}
+
+	// Make sure the next token is a matching ']'.
+	if err = p.next(); err != nil {
+		return
+	}
+
+	switch p.tok.Type {
+	case token.TokenType(']'):
+		break
+
+	case token.NewLine:
+		err = p.parseErrorHere(fmt.Sprintf("unexpected end of line in %s", declarationDesc))
+
+	case token.EndOfFile:
+		err = p.parseErrorHere(fmt.Sprintf("unexpected end of file in %s", declarationDesc))
+
+	default:
+		err = p.parseErrorHere("expected ']'")
+	}
+
+	if err != nil {
+		return
+	}
+
+	// Return a set type.
+	decl = declarations.NewSetType(elementType, nilable)
+	return
+}
+
+// Parse a type declared in an imported interface.
+//
+// Invoked with the import name as the current token.
+func (p *sourceParser) parseImportedType(importDecl *declarations.Import, declarationDesc string, nilable bool) (decl declarations.Type, err error) {
+	// The import name should be followed by a '.' and the type name.
+	if err = p.next(); err != nil {
+		return
+	}
+
+	switch p.tok.Type {
+	case token.TokenType('.'):
+		break
+
+	case token.NewLine:
+		return nil, p.parseErrorHere(fmt.Sprintf("unexpected end of line in %s", declarationDesc))
+
+	case token.EndOfFile:
+		return nil, p.parseErrorHere(fmt.Sprintf("unexpected end of file in %s", declarationDesc))
+
+	default:
+		return nil, p.parseErrorHere("expected '.' following import name")
+	}
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	if p.tok.Type != token.Identifier {
+		return nil, p.parseErrorHere(fmt.Sprintf("expected type name from import '%s'", importDecl.Name))
+	}
+
+	if structDecl, ok := importDecl.Interface.Structs[p.tok.StringValue]; ok {
+		decl = declarations.NewImportedStructType(importDecl, structDecl, nilable)
+	} else if enumDecl, ok := importDecl.Interface.Enums[p.tok.StringValue]; ok {
+		decl = declarations.NewImportedEnumType(importDecl, enumDecl, nilable)
+	} else if unionDecl, ok := importDecl.Interface.Unions[p.tok.StringValue]; ok {
+		decl = declarations.NewImportedUnionType(importDecl, unionDecl, nilable)
+	} else if typedefDecl, ok := importDecl.Interface.Typedefs[p.tok.StringValue]; ok {
+		decl = declarations.NewImportedTypedefType(importDecl, typedefDecl, nilable)
+	} else {
+		err = p.parseErrorHere(fmt.Sprintf("unknown type '%s' in import '%s'", p.tok.StringValue, importDecl.Name))
+	}
+
+	return
+}
+
+// Check that a type is allowed as map keys or set elements.
+//
+// The check is deferred until the type is resolved. The description is used
+// in errors, e.g. "map keys".
+func (p *sourceParser) checkKeyType(keyType declarations.Type, keyTok token.Token, desc string) error {
+	return p.whenResolved(func() (err error) {
+		var resolvedKeyType declarations.Type
+		if resolvedKeyType, err = p.resolveType(keyType); err != nil {
+			return
+		}
+
+		switch declarations.UnderlyingType(resolvedKeyType).Class() {
+		case declarations.StructClass:
+			err = p.parseErrorForToken(fmt.Sprintf("structs are not allowed as %s", desc), &keyTok)
+
+		case declarations.UnionClass:
+			err = p.parseErrorForToken(fmt.Sprintf("unions are not allowed as %s", desc), &keyTok)
+
+		case declarations.MapClass:
+			err = p.parseErrorForToken(fmt.Sprintf("maps are not allowed as %s", desc), &keyTok)
+
+		case declarations.ListClass:
+			err = p.parseErrorForToken(fmt.Sprintf("arrays are not allowed as %s", desc), &keyTok)
+
+		case declarations.SetClass:
+			err = p.parseErrorForToken(fmt.Sprintf("sets are not allowed as %s", desc), &keyTok)
+
+		case declarations.BinaryClass:
+			err = p.parseErrorForToken(fmt.Sprintf("binary values are not allowed as %s", desc), &keyTok)
+
+		case declarations.TimestampClass:
+			err = p.parseErrorForToken(fmt.Sprintf("timestamps are not allowed as %s", desc), &keyTok)
+		}
+
+		return
+	}, keyType)
+}
@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"testing"
+)
+
+func TestTypes(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"timestamp", "struct Event {\n    1: At timestamp\n    2: Until *timestamp\n    3: History []timestamp\n}\n", ""},
+		{"duration", "struct Event {\n    1: Length duration\n    2: Timeout *duration\n    3: Spans map[duration]string\n}\n", ""},
+		{"timestamp typedef", "typedef Deadline timestamp\n", ""},
+		{"contextual keyword arguments", "service Log {\n    Record(1: timestamp int64, 2: duration int64, 3: set bool) string\n}\n", ""},
+		{"timestamp map key", "struct Event {\n    1: Spans map[timestamp]string\n}\n", "timestamps are not allowed as map keys"},
+		{"unknown type", "struct Event {\n    1: At Timestamp\n}\n", "unknown type 'Timestamp'"},
+	})
+}
+
+func TestSets(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"simple", "struct Thing {\n    1: Tags set[string]\n    2: IDs *set[uint64]\n}\n", ""},
+		{"enum", "enum Color {\n    1: Red\n}\n\nstruct Thing {\n    1: Colors set[Color]\n}\n", ""},
+		{"nested", "struct Thing {\n    1: Groups []set[uint32]\n    2: Named map[string]set[int64]\n}\n", ""},
+		{"typedef", "typedef Tags set[string]\n", ""},
+		{"typedef element", "typedef Name string\n\nstruct Thing {\n    1: Names set[Name]\n}\n", ""},
+		{"argument and result", "service Things {\n    Tag(1: tags set[string]) set[string]\n}\n", ""},
+		{"nilable element", "struct Thing {\n    1: Tags set[*string]\n}\n", "nilable types are not allowed as set elements"},
+		{"binary element", "struct Thing {\n    1: Blobs set[binary]\n}\n", "binary values are not allowed as set elements"},
+		{"binary map key", "struct Thing {\n    1: Blobs map[binary]string\n}\n", "binary values are not allowed as map keys"},
+		{"timestamp element", "struct Thing {\n    1: Times set[timestamp]\n}\n", "timestamps are not allowed as set elements"},
+		{"list element", "struct Thing {\n    1: Lists set[[]string]\n}\n", "arrays are not allowed as set elements"},
+		{"set element", "struct Thing {\n    1: Sets set[set[string]]\n}\n", "sets are not allowed as set elements"},
+		{"struct element", "struct Item {\n    1: Name string\n}\n\nstruct Thing {\n    1: Items set[Item]\n}\n", "structs are not allowed as set elements"},
+		{"aliased binary element", "typedef Blob binary\n\nstruct Thing {\n    1: Blobs set[Blob]\n}\n", "binary values are not allowed as set elements"},
+		{"missing element type", "struct Thing {\n    1: Tags set string\n}\n", "expected '['"},
+		{"unterminated", "struct Thing {\n    1: Tags set[string\n}\n", "unexpected end of line in struct field declaration"},
+	})
+}
+
+func TestTimeTypes(t *testing.T) {
+	decl := mustParseTestSource(t, `struct Event {
+    1: At timestamp
+    2: Length *duration
+}
+`)
+
+	fields := decl.Structs["Event"].Fields
+
+	if fields[0].Type != declarations.TimestampType {
+		t.Errorf("expected timestamp type of At, got %v", fields[0].Type)
+	}
+
+	if fields[1].Type != declarations.NilableDurationType {
+		t.Errorf("expected nilable duration type of Length, got %v", fields[1].Type)
+	}
+}
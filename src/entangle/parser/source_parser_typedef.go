@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"entangle/token"
+)
+
+// Parse a type alias declaration.
+func (p *sourceParser) parseTypedef() (err error) {
+	contextDesc := "typedef declaration"
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// Parse the name.
+	var name string
+
+	switch p.tok.Type {
+	case token.NewLine:
+		return p.parseErrorHeref("unexpected end of line in %s", contextDesc)
+
+	case token.EndOfFile:
+		return p.parseErrorHeref("unexpected end of file in %s", contextDesc)
+
+	case token.Identifier:
+		if err = p.validateTypeName(&p.tok); err != nil {
+			return
+		}
+
+		name = p.tok.StringValue
+
+		if p.decl.NameInUse(name) {
+			return p.parseErrorHeref("typedef name '%s' would override previous type declaration", name)
+		}
+
+		p.typeDeclarationTokens = append(p.typeDeclarationTokens, p.tok)
+
+	default:
+		return p.parseErrorHere("expected typedef name")
+	}
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// Then the aliased type.
+	var aliasedType declarations.Type
+	if aliasedType, err = p.parseType(contextDesc); err != nil {
+		return
+	}
+
+	if aliasedType.Nilable() {
+		return p.parseErrorHere("aliased types cannot be nilable")
+	}
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// The type should be followed by an end of line or file.
+	switch p.tok.Type {
+	case token.NewLine, token.EndOfFile:
+		break
+
+	default:
+		return p.parseErrorHere("expected new line following typedef declaration")
+	}
+
+	// Create and add the type alias declaration.
+	decl := declarations.NewTypedef(name, p.documentationParagraphs(), aliasedType)
+	if decl.Annotations, err = p.declarationAnnotations(typedefTarget); err != nil {
+		return
+	}
+	p.decl.AddTypedef(decl)
+
+	return p.next()
+}
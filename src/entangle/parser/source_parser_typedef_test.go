@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"testing"
+)
+
+func TestTypedefs(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"scalar", "typedef UserID uint64\n", ""},
+		{"list", "typedef Names []string\n", ""},
+		{"map", "typedef Counts map[string]uint64\n", ""},
+		{"struct", "struct User {\n    1: Name string\n}\n\ntypedef Users []User\n", ""},
+		{"typedef", "typedef UserID uint64\ntypedef OwnerID UserID\n", ""},
+		{"nilable element", "typedef Names []*string\n", ""},
+		{"used in field", "typedef UserID uint64\n\nstruct User {\n    1: ID UserID\n}\n", ""},
+		{"invalid name", "typedef userID uint64\n", "'userID' is not a valid type name. Type names must be upper camel case"},
+		{"duplicate name", "typedef UserID uint64\ntypedef UserID string\n", "typedef name 'UserID' would override previous type declaration"},
+		{"struct name", "struct User {\n    1: Name string\n}\n\ntypedef User string\n", "typedef name 'User' would override previous type declaration"},
+		{"missing type", "typedef UserID\n", "unexpected end of line in typedef declaration"},
+		{"nilable", "typedef UserID *uint64\n", "aliased types cannot be nilable"},
+		{"unknown type", "typedef UserID Identifier\n", "unknown type 'Identifier'"},
+		{"trailing token", "typedef UserID uint64 uint32\n", "expected new line following typedef declaration"},
+	})
+}
+
+func TestTypedefResolution(t *testing.T) {
+	decl := mustParseTestSource(t, `typedef OwnerID UserID
+typedef UserID uint64
+`)
+
+	ownerType := decl.Typedefs["OwnerID"].Type
+	if ownerType.Class() != declarations.TypedefClass {
+		t.Fatalf("expected OwnerID to alias a typedef, got class %v", ownerType.Class())
+	}
+
+	if underlying := declarations.UnderlyingType(ownerType); underlying.Class() != declarations.Uint64Class {
+		t.Errorf("expected OwnerID to be a uint64, got class %v", underlying.Class())
+	}
+}
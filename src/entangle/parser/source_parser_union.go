@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"entangle/token"
+)
+
+// Parse a union declaration.
+func (p *sourceParser) parseUnion() (err error) {
+	contextDesc := "union declaration"
+	memberContextDesc := "union member declaration"
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	// Parse the name.
+	var name string
+
+	switch p.tok.Type {
+	case token.NewLine:
+		return p.parseErrorHeref("unexpected end of line in %s", contextDesc)
+
+	case token.EndOfFile:
+		return p.parseErrorHeref("unexpected end of file in %s", contextDesc)
+
+	case token.Identifier:
+		if err = p.validateTypeName(&p.tok); err != nil {
+			return
+		}
+
+		name = p.tok.StringValue
+
+		if p.decl.NameInUse(name) {
+			return p.parseErrorHeref("union name '%s' would override previous type declaration", name)
+		}
+
+		p.typeDeclarationTokens = append(p.typeDeclarationTokens, p.tok)
+
+	default:
+		return p.parseErrorHere("expected union name")
+	}
+
+	// Skip new lines.
+	if err = p.nextAndSkipNewLines(); err != nil {
+		return
+	}
+
+	// At this point, we should be met with an opening curly brace.
+	if err = p.expectRune('{', contextDesc); err != nil {
+		return
+	}
+
+	decl := declarations.NewUnion(name, p.documentationParagraphs())
+	if decl.Annotations, err = p.declarationAnnotations(unionTarget); err != nil {
+		return
+	}
+
+	// From here on out, we should be getting documentation and member
+	// definitions. Members are never optional, as exactly one member is
+	// present in a union value.
+	if err = p.parseFields(&decl.FieldList, contextDesc, memberContextDesc, false); err != nil {
+		return
+	}
+
+	if len(decl.Fields) == 0 {
+		return p.parseErrorHere("unions must declare at least one member")
+	}
+
+	// Here, we should be met with a closing curly brace and a new line or
+	// end of file.
+	if err = p.expectRune('}', contextDesc); err != nil {
+		return
+	}
+
+	switch p.tok.Type {
+	case token.NewLine, token.EndOfFile:
+		break
+
+	default:
+		return p.parseErrorHere("expected new line following '}'")
+	}
+
+	// Add the declaration to the interface declaration.
+	p.decl.AddUnion(decl)
+
+	return p.next()
+}
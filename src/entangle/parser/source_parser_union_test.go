@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"testing"
+)
+
+func TestUnions(t *testing.T) {
+	runParseTestCases(t, []parseTestCase{
+		{"members", "union Value {\n    1: Text string\n    2: Number int64\n}\n", ""},
+		{"sparse indexes", "union Value {\n    1: Text string\n    3: Number int64\n}\n", ""},
+		{"reservation", "union Value {\n    1: Text string\n    reserved 2\n}\n", ""},
+		{"declared types", "struct Point {\n    1: X int64\n}\n\nunion Value {\n    1: Text string\n    2: Point Point\n    3: Points []Point\n}\n", ""},
+		{"forward reference", "union Value {\n    1: Text string\n    2: Point Point\n}\n\nstruct Point {\n    1: X int64\n}\n", ""},
+		{"argument named union", "union Value {\n    1: Text string\n}\n\nservice Values {\n    Set(1: union Value)\n}\n", ""},
+		{"no members", "union Value {\n}\n", "unions must declare at least one member"},
+		{"zero index", "union Value {\n    0: Text string\n}\n", "field indexes are 1-based"},
+		{"duplicate index", "union Value {\n    1: Text string\n    1: Number int64\n}\n", "field index 1 already in use"},
+		{"duplicate name", "union Value {\n    1: Text string\n    2: Text int64\n}\n", "field name 'Text' already in use"},
+		{"unknown type", "union Value {\n    1: Point Point\n}\n", "unknown type 'Point'"},
+		{"nilable member", "union Value {\n    1: Text *string\n}\n", "nilable types are not allowed in union member declaration"},
+		{"duplicate union", "union Value {\n    1: Text string\n}\n\nunion Value {\n    1: Text string\n}\n", "union name 'Value' would override previous type declaration"},
+		{"invalid name", "union value {\n    1: Text string\n}\n", "'value' is not a valid type name. Type names must be upper camel case"},
+		{"missing name", "union\n", "unexpected end of line in union declaration"},
+		{"trailing tokens", "union Value {\n    1: Text string\n} Text\n", "expected new line following '}'"},
+	})
+}
+
+func TestUnionDeclaration(t *testing.T) {
+	decl := mustParseTestSource(t, `// Value of a setting.
+union Value {
+    2: Number int64
+    1: Text string
+}
+`)
+
+	union := decl.Unions["Value"]
+	if union == nil {
+		t.Fatalf("expected union Value")
+	}
+
+	if len(union.Documentation) != 1 || union.Documentation[0] != "Value of a setting." {
+		t.Errorf("expected documentation of Value, got %v", union.Documentation)
+	}
+
+	members := union.FieldsSortedByIndex()
+	if len(members) != 2 {
+		t.Fatalf("expected 2 members of Value, got %d", len(members))
+	}
+
+	if members[0].Name != "Text" || members[0].Index != 1 || members[0].Type != declarations.StringType {
+		t.Errorf("expected member 1 Text of type string, got %d %s", members[0].Index, members[0].Name)
+	}
+
+	if members[1].Name != "Number" || members[1].Index != 2 || members[1].Type.Nilable() {
+		t.Errorf("expected member 2 Number of a non-nilable type, got %d %s", members[1].Index, members[1].Name)
+	}
+}
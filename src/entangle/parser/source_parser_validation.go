@@ -89,6 +89,19 @@ func (p *sourceParser) validateTypeName(tok *token.Token) error {
 	return nil
 }
 
+// Validate a constant name.
+func (p *sourceParser) validateConstantName(tok *token.Token) error {
+	if _, reserved := reservedIdentifiers[tok.StringValue]; reserved {
+		return p.parseErrorForToken(fmt.Sprintf("'%s' is a reserved identifier", tok.StringValue), tok)
+	}
+
+	if !firstUpperCamelCaseExpression.MatchString(tok.StringValue) {
+		return p.parseErrorForToken(fmt.Sprintf("'%s' is not a valid constant name. Constant names must be upper camel case", tok.StringValue), tok)
+	}
+
+	return nil
+}
+
 // Validate a function name.
 func (p *sourceParser) validateFunctionName(tok *token.Token) error {
 	if _, reserved := reservedIdentifiers[tok.StringValue]; reserved {
@@ -0,0 +1,218 @@
+package parser
+
+import (
+	"entangle/declarations"
+	"entangle/token"
+	"fmt"
+	"math"
+)
+
+// Names of value types used in error descriptions.
+var valueTypeNames = map[declarations.TypeClass]string{
+	declarations.BoolClass:    "bool",
+	declarations.StringClass:  "string",
+	declarations.Float32Class: "float32",
+	declarations.Float64Class: "float64",
+	declarations.Int8Class:    "int8",
+	declarations.Int16Class:   "int16",
+	declarations.Int32Class:   "int32",
+	declarations.Int64Class:   "int64",
+	declarations.Uint8Class:   "uint8",
+	declarations.Uint16Class:  "uint16",
+	declarations.Uint32Class:  "uint32",
+	declarations.Uint64Class:  "uint64",
+}
+
+// Ranges of signed integer types.
+var signedIntegerRanges = map[declarations.TypeClass][2]int64{
+	declarations.Int8Class:  {math.MinInt8, math.MaxInt8},
+	declarations.Int16Class: {math.MinInt16, math.MaxInt16},
+	declarations.Int32Class: {math.MinInt32, math.MaxInt32},
+	declarations.Int64Class: {math.MinInt64, math.MaxInt64},
+}
+
+// Maximum values of unsigned integer types.
+var unsignedIntegerMaximums = map[declarations.TypeClass]uint64{
+	declarations.Uint8Class:  math.MaxUint8,
+	declarations.Uint16Class: math.MaxUint16,
+	declarations.Uint32Class: math.MaxUint32,
+	declarations.Uint64Class: math.MaxUint64,
+}
+
+// Determine if values can be declared for a type.
+func valueTypeSupported(valueType declarations.Type) bool {
+	valueType = declarations.UnderlyingType(valueType)
+
+	if _, ok := valueTypeNames[valueType.Class()]; ok {
+		return true
+	}
+
+	return valueType.Class() == declarations.EnumClass
+}
+
+// Parse a value of a given, resolved type from a token.
+//
+// The dynamic type of the returned value is described by
+// declarations.Constant.
+func (p *sourceParser) parseValue(valueType declarations.Type, tok *token.Token, contextDesc string) (value interface{}, err error) {
+	valueType = declarations.UnderlyingType(valueType)
+
+	typeName := valueTypeNames[valueType.Class()]
+
+	switch valueType.Class() {
+	case declarations.BoolClass:
+		if tok.Type == token.Identifier {
+			switch tok.StringValue {
+			case "true":
+				return true, nil
+
+			case "false":
+				return false, nil
+			}
+		}
+
+		return nil, p.parseErrorForToken("expected 'true' or 'false' as bool value", tok)
+
+	case declarations.StringClass:
+		if tok.Type != token.Literal {
+			return nil, p.parseErrorForToken("expected string literal as string value", tok)
+		}
+
+		return tok.StringValue, nil
+
+	case declarations.Float32Class, declarations.Float64Class:
+		var floatValue float64
+
+		switch tok.Type {
+		case token.FloatConstant:
+			floatValue = tok.FloatValue
+
+		case token.IntConstant:
+			floatValue = float64(tok.IntValue)
+
+		case token.UintConstant:
+			floatValue = float64(tok.UintValue)
+
+		default:
+			return nil, p.parseErrorForToken(fmt.Sprintf("expected number as %s value", typeName), tok)
+		}
+
+		if valueType.Class() == declarations.Float32Class && math.Abs(floatValue) > math.MaxFloat32 {
+			return nil, p.parseErrorForToken(fmt.Sprintf("value out of range for %s", typeName), tok)
+		}
+
+		return floatValue, nil
+
+	case declarations.Int8Class, declarations.Int16Class, declarations.Int32Class, declarations.Int64Class:
+		valueRange := signedIntegerRanges[valueType.Class()]
+
+		switch tok.Type {
+		case token.IntConstant:
+			if tok.IntValue < valueRange[0] {
+				return nil, p.parseErrorForToken(fmt.Sprintf("value out of range for %s", typeName), tok)
+			}
+
+			return tok.IntValue, nil
+
+		case token.UintConstant:
+			if tok.UintValue > uint64(valueRange[1]) {
+				return nil, p.parseErrorForToken(fmt.Sprintf("value out of range for %s", typeName), tok)
+			}
+
+			return int64(tok.UintValue), nil
+
+		default:
+			return nil, p.parseErrorForToken(fmt.Sprintf("expected integer as %s value", typeName), tok)
+		}
+
+	case declarations.Uint8Class, declarations.Uint16Class, declarations.Uint32Class, declarations.Uint64Class:
+		switch tok.Type {
+		case token.IntConstant:
+			return nil, p.parseErrorForToken(fmt.Sprintf("value out of range for %s", typeName), tok)
+
+		case token.UintConstant:
+			if tok.UintValue > unsignedIntegerMaximums[valueType.Class()] {
+				return nil, p.parseErrorForToken(fmt.Sprintf("value out of range for %s", typeName), tok)
+			}
+
+			return tok.UintValue, nil
+
+		default:
+			return nil, p.parseErrorForToken(fmt.Sprintf("expected unsigned integer as %s value", typeName), tok)
+		}
+
+	case declarations.EnumClass:
+		enumDecl := valueType.(*declarations.EnumType).Enum()
+
+		if tok.Type != token.Identifier {
+			return nil, p.parseErrorForToken(fmt.Sprintf("expected value name of enumeration '%s'", enumDecl.Name), tok)
+		}
+
+		enumValue, found := enumDecl.ValueByName(tok.StringValue)
+		if !found {
+			return nil, p.parseErrorForToken(fmt.Sprintf("unknown value '%s' of enumeration '%s'", tok.StringValue, enumDecl.Name), tok)
+		}
+
+		return enumValue, nil
+
+	default:
+		return nil, p.parseErrorForToken(fmt.Sprintf("values are not supported for the type in %s", contextDesc), tok)
+	}
+}
+
+// Parse a value of a given type.
+//
+// Invoked with the first token of the value as the current token, which is
+// left in place. Parsing is deferred until the type is resolved if necessary,
+// and the value is stored in the target once parsed.
+func (p *sourceParser) parseValueInto(valueType declarations.Type, contextDesc string, target *interface{}) (err error) {
+	switch p.tok.Type {
+	case token.NewLine:
+		return p.parseErrorHeref("unexpected end of line in %s, expected value", contextDesc)
+
+	case token.EndOfFile:
+		return p.parseErrorHeref("unexpected end of file in %s, expected value", contextDesc)
+	}
+
+	valueTok := p.tok
+
+	return p.whenResolved(func() (err error) {
+		var resolvedType declarations.Type
+		if resolvedType, err = p.resolveType(valueType); err != nil {
+			return
+		}
+
+		if !valueTypeSupported(resolvedType) {
+			return p.parseErrorForToken(fmt.Sprintf("values in %s must be of a bool, string, numeric or enumeration type", contextDesc), &valueTok)
+		}
+
+		*target, err = p.parseValue(resolvedType, &valueTok, contextDesc)
+		return
+	}, valueType)
+}
+
+// Parse an optional default value of a given type.
+//
+// Invoked with the token following the type as the current token. If the token
+// is an equals sign ('='), the default value is parsed into the target with the
+// token following the value as the current token. Otherwise, the current token
+// is left in place.
+func (p *sourceParser) parseDefaultValue(valueType declarations.Type, contextDesc string, target *interface{}) (err error) {
+	if p.tok.Type != token.TokenType('=') {
+		return
+	}
+
+	if valueType.Nilable() {
+		return p.parseErrorHere("default values cannot be declared for nilable types")
+	}
+
+	if err = p.next(); err != nil {
+		return
+	}
+
+	if err = p.parseValueInto(valueType, contextDesc, target); err != nil {
+		return
+	}
+
+	return p.next()
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"os"
 	"unicode"
 )
 
@@ -135,3 +136,14 @@ func FromBytes(data []byte, path string) (s *Source, err error) {
 func FromString(data string, path string) (s *Source, err error) {
 	return FromBytes([]byte(data), path)
 }
+
+// Source from file.
+func FromFile(path string) (s *Source, err error) {
+	var f *os.File
+	if f, err = os.Open(path); err != nil {
+		return
+	}
+	defer f.Close()
+
+	return FromReader(f, path)
+}
@@ -1,6 +1,10 @@
 // Package token provides the token interchanged between the lexer and parser.
 package token
 
+import (
+	"time"
+)
+
 // Token.
 type Token struct {
 	// Token type.
@@ -23,4 +27,7 @@ type Token struct {
 
 	// Floating point value.
 	FloatValue float64
+
+	// Duration value.
+	DurationValue time.Duration
 }
@@ -33,6 +33,7 @@ const (
 	IntConstant
 	UintConstant
 	FloatConstant
+	DurationConstant
 
 	/**
 	 * Base data type tokens.
@@ -78,6 +79,7 @@ var tokenTypeName = map[TokenType]string{
 	IntConstant:       "IntConstant",
 	UintConstant:      "UintConstant",
 	FloatConstant:     "FloatConstant",
+	DurationConstant:  "DurationConstant",
 	Bool:              "Bool",
 	String:            "String",
 	Binary:            "Binary",
@@ -111,6 +113,7 @@ var tokenTypeRepresentation = map[TokenType]string{
 	IntConstant:       "integer constant",
 	UintConstant:      "unsigned integer constant",
 	FloatConstant:     "floating point constant",
+	DurationConstant:  "duration constant",
 	Bool:              "bool",
 	String:            "string",
 	Binary:            "binary",